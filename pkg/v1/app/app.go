@@ -13,13 +13,17 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io/fs"
-	"os"
+	"slices"
+	"strings"
+	"sync"
 
+	"github.com/mirkobrombin/go-cli-builder/v2/pkg/help"
 	"github.com/vanilla-os/sdk/pkg/v1/app/types"
 	"github.com/vanilla-os/sdk/pkg/v1/cli"
 	"github.com/vanilla-os/sdk/pkg/v1/i18n"
 	"github.com/vanilla-os/sdk/pkg/v1/logs"
 	"github.com/vorlif/spreak"
+	"golang.org/x/text/language"
 )
 
 // App represents a Vanilla OS application
@@ -39,7 +43,8 @@ type App struct {
 	// Log is the logger for the application
 	Log *logs.Logger
 
-	// LC (Localizer) is the localizer for the application
+	// LC (Localizer) is the localizer for the application, use SetLocale
+	// to switch it at runtime and Trans to use it from other goroutines
 	LC spreak.Localizer
 
 	// LocalesFS is the file system containing the locales for the application
@@ -47,6 +52,13 @@ type App struct {
 
 	// CLI is the command line interface for the application
 	CLI *cli.Command
+
+	mu             sync.RWMutex
+	hasLocalizer   bool
+	localeHandlers []func(language.Tag)
+
+	// pseudoLocale is the active pseudo-locale, if any
+	pseudoLocale string
 }
 
 // NewApp creates a new Vanilla OS application, which can be used to
@@ -99,34 +111,20 @@ func NewApp(options types.AppOptions) (*App, error) {
 	}
 	app.Log = &logger
 
-	// here we prepare a localizer for the application
-	locale := os.Getenv("LANGUAGE")
-	if locale == "" {
-		locale = os.Getenv("LC_ALL")
-	}
-	if locale == "" {
-		locale = os.Getenv("LC_MESSAGES")
+	// here we prepare a localizer for the application, negotiating the
+	// locales preferred by the user, then the default one. The
+	// pseudo-locales work without catalogs
+	locales := i18n.UserLocales()
+	if options.DefaultLocale != "" {
+		locales = append(locales, options.DefaultLocale)
 	}
-	if locale == "" {
-		locale = os.Getenv("LANG")
-	}
-	if locale == "" {
-		locale = options.DefaultLocale
-	}
-
-	var localizer *spreak.Localizer
-	if options.LocalesFS != nil {
-		var err error
-		localizer, err = i18n.NewLocalizer(options.LocalesFS, app.RDNN, locale)
-		if err != nil {
+	locale := strings.Join(locales, ":")
+	if _, pseudo := i18n.PseudoTranslator(locale); options.LocalesFS != nil || pseudo != nil {
+		if err := app.loadLocale(locale); err != nil {
 			return &app, err
 		}
 	}
 
-	if localizer != nil {
-		app.LC = *localizer
-	}
-
 	app.checkMissingStrings()
 
 	return &app, nil
@@ -147,10 +145,217 @@ func (app *App) WithCLI(root any) error {
 	if err != nil {
 		return err
 	}
+	cmd.SetLogger(app.Log)
+
+	app.mu.RLock()
+	pseudo := app.pseudoLocale != ""
+	app.mu.RUnlock()
+	if app.LocalesFS != nil || pseudo {
+		cmd.SetTranslator(app.Trans)
+		cmd.SetLanguage(app.Locale().String())
+	}
 	app.CLI = cmd
 	return nil
 }
 
+// loadLocale creates the localizer for a locale, or a colon separated list
+// of locales, and makes it the active one. Pseudo-locales translate the
+// English strings, if a catalog is available, or the source strings.
+func (app *App) loadLocale(locale string) error {
+	var localizer *spreak.Localizer
+	var err error
+	pseudoLocale, pseudo := i18n.PseudoTranslator(locale)
+	switch {
+	case pseudo != nil:
+		localizer, err = i18n.NewPseudoLocalizer(app.LocalesFS, app.RDNN, pseudoLocale)
+	case app.LocalesFS != nil:
+		localizer, err = i18n.NewLocalizer(app.LocalesFS, app.RDNN, locale)
+	}
+	if err != nil {
+		return err
+	}
+
+	app.mu.Lock()
+	if localizer != nil {
+		app.LC = *localizer
+		app.hasLocalizer = true
+	}
+	app.pseudoLocale = pseudoLocale
+	app.mu.Unlock()
+	return nil
+}
+
+// SetLocale switches the application to another locale at runtime, e.g.
+// when the user changes the language from the settings of a GUI. The
+// locale can be a colon separated list of locales in order of preference
+// and is negotiated against the languages listed in the LINGUAS file, as
+// done at startup. The CLI and the functions registered with
+// OnLocaleChange are updated immediately. The pseudo-locales
+// i18n.PseudoLocale and i18n.PseudoLocaleRTL are accepted as well.
+//
+// Example:
+//
+//	if err := app.SetLocale("pt_BR.UTF-8"); err != nil {
+//		return err
+//	}
+//	fmt.Println(app.Trans("I am Batman!"))
+func (app *App) SetLocale(locale string) error {
+	if _, pseudo := i18n.PseudoTranslator(locale); app.LocalesFS == nil && pseudo == nil {
+		return fmt.Errorf("no locales available. Set LocalesFS in the application options")
+	}
+	if err := app.loadLocale(locale); err != nil {
+		return err
+	}
+
+	tag := app.Locale()
+	if app.CLI != nil {
+		app.CLI.SetLanguage(tag.String())
+	}
+
+	app.mu.RLock()
+	handlers := slices.Clone(app.localeHandlers)
+	app.mu.RUnlock()
+	for _, handler := range handlers {
+		handler(tag)
+	}
+	return nil
+}
+
+// Locale returns the language of the active localizer, the pseudo-locale
+// if one is active, or language.Und if the strings are not translated.
+//
+// Example:
+//
+//	fmt.Printf("Speaking %s\n", app.Locale())
+func (app *App) Locale() language.Tag {
+	app.mu.RLock()
+	defer app.mu.RUnlock()
+	if app.pseudoLocale != "" {
+		return i18n.LocaleTag(app.pseudoLocale)
+	}
+	if !app.hasLocalizer {
+		return language.Und
+	}
+	return app.LC.Language()
+}
+
+// OnLocaleChange registers a function called with the new language every
+// time the locale is switched with SetLocale, e.g. to refresh a GUI.
+//
+// Example:
+//
+//	app.OnLocaleChange(func(tag language.Tag) {
+//		window.SetTitle(app.Trans("BatSignal"))
+//	})
+func (app *App) OnLocaleChange(handler func(tag language.Tag)) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	app.localeHandlers = append(app.localeHandlers, handler)
+}
+
+// Trans translates a string, or a "pr:" key, using the active localizer.
+// Unlike reading App.LC directly, it is safe to call while the locale is
+// being switched. The string is returned as it is if no localizer is
+// available.
+//
+// Example:
+//
+//	fmt.Println(app.Trans("I am Batman!"))
+func (app *App) Trans(key string) string {
+	key = strings.TrimPrefix(key, "pr:")
+
+	app.mu.RLock()
+	lc, ok := app.LC, app.hasLocalizer
+	app.mu.RUnlock()
+	if !ok {
+		return key
+	}
+	return lc.Get(key)
+}
+
+// NTrans translates a string with a plural form, choosing the form
+// matching n in the active locale.
+//
+// Example:
+//
+//	msg := fmt.Sprintf(app.NTrans("%d villain spotted", "%d villains spotted", n), n)
+func (app *App) NTrans(singular, plural string, n int) string {
+	app.mu.RLock()
+	lc, ok := app.LC, app.hasLocalizer
+	app.mu.RUnlock()
+
+	switch {
+	case ok:
+		return lc.NGet(singular, plural, n)
+	case n == 1:
+		return singular
+	}
+	return plural
+}
+
+// Format returns a formatter for numbers, sizes, dates, times and lists
+// bound to the active locale. If the strings are not translated, the
+// first locale preferred by the user is used, so that the values still
+// follow the user conventions. Locales not supported by i18n.NewFormatter
+// are formatted entirely in English.
+//
+// Example:
+//
+//	fmt.Printf("%s free\n", app.Format().SizeIEC(free))
+//	fmt.Printf("updated %s\n", app.Format().RelativeTime(lastUpdate))
+func (app *App) Format() *i18n.Formatter {
+	tag := app.Locale()
+	if tag == language.Und {
+		if locales := i18n.UserLocales(); len(locales) > 0 {
+			tag = i18n.LocaleTag(locales[0])
+		}
+	}
+	f, err := i18n.NewFormatter(tag)
+	if err != nil {
+		f, _ = i18n.NewFormatter(language.English)
+	}
+	return f
+}
+
+// GenerateManPages writes the man pages of the application CLI into dir:
+// the default page into dir/man1 and one page per language listed in the
+// LINGUAS file of LocalesFS into dir/<lang>/man1, each one translated with
+// the catalog of its language. Use cli.InstallManPages to install them.
+//
+// Example:
+//
+//	files, err := app.GenerateManPages("man")
+//	if err != nil {
+//		return err
+//	}
+//	for _, file := range files {
+//		fmt.Println("Generated", file)
+//	}
+func (app *App) GenerateManPages(dir string) ([]string, error) {
+	if app.CLI == nil {
+		return nil, fmt.Errorf("no CLI initialized. Use WithCLI")
+	}
+
+	locales := make(map[string]help.Translator)
+	if app.LocalesFS != nil {
+		languages, err := i18n.Languages(app.LocalesFS)
+		if err != nil {
+			return nil, err
+		}
+		for _, lang := range languages {
+			localizer, err := i18n.NewLocalizer(app.LocalesFS, app.RDNN, lang)
+			if err != nil {
+				return nil, fmt.Errorf("cannot load the %s locale: %w", lang, err)
+			}
+			locales[lang] = func(s string) string {
+				return localizer.Get(s)
+			}
+		}
+	}
+
+	return app.CLI.GenerateManPages(dir, locales)
+}
+
 // generateAppSign generates a unique signature for the application
 // based on the RDNN, name and version. The signature is used to
 // identify the application.
@@ -12,16 +12,23 @@ package app
 */
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/vanilla-os/sdk/pkg/v1/i18n"
+	"github.com/vanilla-os/sdk/pkg/v1/i18n/types"
 )
 
 // checkMissingStrings is a hook used when the "check_missing_strings" build tag is set.
-// It scans the project for missing translation keys and exists with a non-zero status if any are found.
+// It checks the catalogs of every locale against the strings used in the project,
+// writes the JSON and JUnit reports to the paths given by the i18n.CheckReportJSONEnv
+// and i18n.CheckReportJUnitEnv environment variables, if set, and exits with a
+// non-zero status if strings are missing from the English catalog, or from any
+// catalog when there is no English one.
 func (a *App) checkMissingStrings() {
 	rootDir, err := os.Getwd()
 	if err != nil {
@@ -29,53 +36,71 @@ func (a *App) checkMissingStrings() {
 		os.Exit(1)
 	}
 
-	// Try to locate the English locale file in common paths.
-	var localeFile string
-	possiblePaths := []string{
-		"cmd/locales/en/LC_MESSAGES",
-		"cmd/locales/en",
-		"locales/en/LC_MESSAGES",
-		"locales/en",
-		"assets/locales/en/LC_MESSAGES",
-		"assets/locales/en",
-	}
-
-	for _, p := range possiblePaths {
+	// Try to locate the locales directory in common paths.
+	var localesDir string
+	for _, p := range []string{"cmd/locales", "locales", "assets/locales"} {
 		path := filepath.Join(rootDir, p)
-		matches, _ := filepath.Glob(filepath.Join(path, "*.po"))
-		if len(matches) > 0 {
-			localeFile = matches[0]
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			localesDir = path
 			break
 		}
 	}
 
-	if localeFile == "" {
-		fmt.Println("Error: Could not find any .po file in standard locations")
+	if localesDir == "" {
+		fmt.Println("Error: Could not find a locales directory in standard locations")
 		os.Exit(1)
 	}
 
-	fmt.Printf("Checking missing strings in %s using locale %s\n", rootDir, localeFile)
+	fmt.Printf("Checking translation strings in %s using the catalogs in %s\n", rootDir, localesDir)
 
-	missing, err := i18n.CheckMissingStrings(rootDir, localeFile)
+	report, err := i18n.CheckLocales(rootDir, localesDir, types.ExtractOptions{})
 	if err != nil {
 		fmt.Printf("Error checking strings: %v\n", err)
 		os.Exit(1)
 	}
+	if len(report.Locales) == 0 {
+		fmt.Println("Error: Could not find any .po file in", localesDir)
+		os.Exit(1)
+	}
 
-	if len(missing) > 0 {
-		fmt.Println("Oops, there are missing translation strings:")
-		for file, keys := range missing {
-			for _, key := range keys {
-				fmt.Printf("- %s: Missing '%s'\n", file, key)
+	writeReport := func(env string, write func(io.Writer, *types.CheckReport) error) {
+		name := os.Getenv(env)
+		if name == "" {
+			return
+		}
+		f, err := os.Create(name)
+		if err == nil {
+			err = write(f, report)
+			if errClose := f.Close(); err == nil {
+				err = errClose
 			}
 		}
+		if err != nil {
+			fmt.Printf("Error writing %s: %v\n", name, err)
+			os.Exit(1)
+		}
+	}
+	writeReport(i18n.CheckReportJSONEnv, i18n.WriteCheckJSON)
+	writeReport(i18n.CheckReportJUnitEnv, i18n.WriteCheckJUnit)
 
-		f, _ := os.Create("missing_strings.json")
-		defer f.Close()
-		enc := json.NewEncoder(f)
-		enc.SetIndent("", "  ")
-		enc.Encode(missing)
+	hasEnglish := slices.ContainsFunc(report.Locales, func(l types.LocaleReport) bool {
+		return l.Locale == "en"
+	})
+	failed := false
+	for _, locale := range report.Locales {
+		fmt.Printf("- %s: %.1f%% translated, %d missing, %d untranslated, %d fuzzy, %d unused\n",
+			locale.Locale, locale.Coverage, len(locale.Missing), len(locale.Untranslated),
+			len(locale.Fuzzy), len(locale.Unused))
+		if len(locale.Missing) > 0 && (locale.Locale == "en" || !hasEnglish) {
+			failed = true
+			for _, entry := range locale.Missing {
+				fmt.Printf("  - Missing '%s' (%s)\n", entry.ID, strings.Join(entry.References, ", "))
+			}
+		}
+	}
 
+	if failed {
+		fmt.Println("Oops, there are missing translation strings!")
 		os.Exit(1)
 	}
 
@@ -9,10 +9,17 @@ package tests
 */
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/vanilla-os/sdk/pkg/v1/app"
 	"github.com/vanilla-os/sdk/pkg/v1/app/types"
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+	"github.com/vanilla-os/sdk/pkg/v1/i18n"
+	"golang.org/x/text/language"
 )
 
 func TestNewApp(t *testing.T) {
@@ -37,3 +44,190 @@ func TestNewApp(t *testing.T) {
 	app.Log.File.Info().Msg("Robin reached the file logger")
 	app.Log.Term.Info().Msg("Robin reached the console logger")
 }
+
+type manGadgetCmd struct {
+	cli.Base
+}
+
+type manRootCmd struct {
+	cli.Base `man-see-also:"apx(1)"`
+	Use      manGadgetCmd `cmd:"use" help:"pr:use.help"`
+}
+
+func TestGenerateManPages(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "0")
+	for _, env := range []string{"LANGUAGE", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		t.Setenv(env, "")
+	}
+
+	locales := fstest.MapFS{
+		"LINGUAS": {Data: []byte("en it de\n")},
+		"en/LC_MESSAGES/com.vanilla-os.batctl.po": {Data: []byte(`msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: en\n"
+
+msgid "use.help"
+msgstr "Use a gadget"
+`)},
+		"it/LC_MESSAGES/com.vanilla-os.batctl.po": {Data: []byte(`msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: it\n"
+
+msgid "use.help"
+msgstr "Usa un gadget"
+
+msgid "SEE ALSO"
+msgstr "VEDI ANCHE"
+`)},
+		"de/LC_MESSAGES/com.vanilla-os.batctl.po": {Data: []byte(`msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: de\n"
+
+msgid "use.help"
+msgstr "Ein Gerät benutzen"
+`)},
+	}
+
+	myApp, err := app.NewApp(types.AppOptions{
+		RDNN:          "com.vanilla-os.batctl",
+		Name:          "BatCtl",
+		Version:       "1.0.0",
+		LocalesFS:     locales,
+		DefaultLocale: "en",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := myApp.WithCLI(&manRootCmd{}); err != nil {
+		t.Fatal(err)
+	}
+	myApp.CLI.SetName("batctl")
+	myApp.CLI.SetTranslator(func(s string) string {
+		return myApp.LC.Get(s)
+	})
+
+	dir := t.TempDir()
+	files, err := myApp.GenerateManPages(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 4 {
+		t.Fatalf("expected 4 man pages, got %q", files)
+	}
+
+	tests := map[string][]string{
+		"man1/batctl.1":    {".SH NAME", ".SH SEE ALSO", "Use a gadget"},
+		"en/man1/batctl.1": {".SH NAME", ".SH SEE ALSO", "Use a gadget"},
+		"it/man1/batctl.1": {".SH NOME", ".SH VEDI ANCHE", "Usa un gadget"},
+		"de/man1/batctl.1": {".SH BEZEICHNUNG", ".SH SIEHE AUCH", "Ein Gerät benutzen"},
+	}
+	for file, expected := range tests {
+		man, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range expected {
+			if !strings.Contains(string(man), s) {
+				t.Errorf("expected %q in %s:\n%s", s, file, man)
+			}
+		}
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	t.Setenv("LANGUAGE", "fr:pt_BR")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "fr_FR.UTF-8")
+
+	catalog := func(lang, translation string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(`msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: ` + lang + `\n"
+"Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+msgid "use.help"
+msgstr "` + translation + `"
+`)}
+	}
+	locales := fstest.MapFS{
+		"LINGUAS": {Data: []byte("it pt\n")},
+		"it/LC_MESSAGES/com.vanilla-os.batctl.po": catalog("it", "Usa un gadget"),
+		"pt/LC_MESSAGES/com.vanilla-os.batctl.po": catalog("pt", "Usar um gadget"),
+	}
+
+	myApp, err := app.NewApp(types.AppOptions{
+		RDNN:          "com.vanilla-os.batctl",
+		Name:          "BatCtl",
+		Version:       "1.0.0",
+		LocalesFS:     locales,
+		DefaultLocale: "it",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := myApp.Trans("pr:use.help"); msg != "Usar um gadget" {
+		t.Errorf("expected pt_BR to fall back to pt, got %q", msg)
+	}
+
+	var changed []language.Tag
+	myApp.OnLocaleChange(func(tag language.Tag) {
+		changed = append(changed, tag)
+	})
+	if err := myApp.SetLocale("it_IT.UTF-8@euro"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := myApp.Trans("use.help"); msg != "Usa un gadget" {
+		t.Errorf("expected the Italian translation after switching, got %q", msg)
+	}
+	if len(changed) != 1 || changed[0] != language.Italian {
+		t.Errorf("unexpected locale change notifications %v", changed)
+	}
+	if msg := myApp.NTrans("%d gadget", "%d gadgets", 2); msg != "%d gadgets" {
+		t.Errorf("unexpected plural %q", msg)
+	}
+}
+
+func TestPseudoLocale(t *testing.T) {
+	t.Setenv(i18n.PseudoLocaleEnv, "")
+	t.Setenv("LANGUAGE", "")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "it_IT.UTF-8")
+
+	myApp, err := app.NewApp(types.AppOptions{
+		RDNN:          "com.vanilla-os.batctl",
+		Name:          "BatCtl",
+		Version:       "1.0.0",
+		DefaultLocale: i18n.PseudoLocale,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := myApp.Trans("pr:Gadget"); msg != "[Ĝååðĝééţ]" {
+		t.Errorf("unexpected pseudo-translation %q", msg)
+	}
+	if msg := myApp.NTrans("%d gadget", "%d gadgets", 2); msg != "[%d ĝååðĝééţš]" {
+		t.Errorf("unexpected plural pseudo-translation %q", msg)
+	}
+	if msg := myApp.LC.Get("Gadget"); msg != "[Ĝååðĝééţ]" {
+		t.Errorf("unexpected pseudo-translation from the localizer %q", msg)
+	}
+	if tag := myApp.Locale(); tag.String() != "en-XA" {
+		t.Errorf("unexpected locale %s", tag)
+	}
+
+	if err := myApp.SetLocale(i18n.PseudoLocaleRTL); err != nil {
+		t.Fatal(err)
+	}
+	if msg := myApp.Trans("Gadget"); msg != "[\u200f\u202eGadget\u202c\u200f]" {
+		t.Errorf("unexpected RTL pseudo-translation %q", msg)
+	}
+	if msg := myApp.LC.NGet("%d gadget", "%d gadgets", 1); msg != "[%d \u200f\u202egadget\u202c\u200f]" {
+		t.Errorf("unexpected RTL pseudo-translation from the localizer %q", msg)
+	}
+}
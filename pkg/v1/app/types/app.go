@@ -35,7 +35,9 @@ type AppOptions struct {
 
 	// DefaultLocale is the default locale for the application, this should
 	// always be empty unless you want to force a specific locale for the
-	// application, for example for testing purposes.
+	// application, for example for testing purposes. Set it to
+	// i18n.PseudoLocale or i18n.PseudoLocaleRTL to pseudo-translate every
+	// string, so that truncated and hardcoded strings stand out.
 	DefaultLocale string
 
 	// CLIOptions contains options for creating the command line interface
@@ -10,14 +10,16 @@ package cli
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
-	"time"
 
 	builder "github.com/mirkobrombin/go-cli-builder/v2/pkg/cli"
 	"github.com/mirkobrombin/go-cli-builder/v2/pkg/help"
 	"github.com/mirkobrombin/go-cli-builder/v2/pkg/parser"
-	"github.com/vanilla-os/sdk/pkg/v1/roff"
+	"github.com/vanilla-os/sdk/pkg/v1/logs"
 )
 
 // Base is an alias for builder.Base to be used by consumers
@@ -29,31 +31,37 @@ type Command struct {
 	Short string
 	Long  string
 
-	root   any
-	app    *builder.App
-	manCmd *ManCmd
+	root any
+	app  *builder.App
+
+	translator  help.Translator
+	output      string
+	assumeYes   bool
+	noInput     bool
+	answersFile string
+	helpTopics  map[string]string
+	language    string
+	middlewares []Middleware
+	logger      *logs.Logger
+	stdout      io.Writer
+	stderr      io.Writer
 }
 
 // ManCmd is the command to generate the man page
 type ManCmd struct {
 	Base
-	root       any
-	translator help.Translator
+
+	cmd *Command
 }
 
 // Run runs the man command
-//
-// Example:
-//
-//	manCmd := &cli.ManCmd{root: s}
-//	err := parser.Run(manCmd)
 func (c *ManCmd) Run() error {
-	man, err := GenerateManPage(c.root, c.translator)
+	man, err := c.cmd.ManPage()
 	if err != nil {
 		return err
 	}
 
-	fmt.Println(man)
+	fmt.Fprint(c.cmd.writer(), man)
 	return nil
 }
 
@@ -62,12 +70,30 @@ func (c *Command) Name() string {
 	return c.Use
 }
 
-// Execute runs the command
+// Execute runs the command. Errors returned by the command are rendered to
+// the error output, recorded in the log file set with SetLogger and
+// returned as an *Error carrying the exit code of the program.
 func (c *Command) Execute() error {
 	if c.app == nil {
 		return fmt.Errorf("no application initialized. Use NewCommandFromStruct")
 	}
-	return c.app.Run()
+	if len(os.Args) > 1 && os.Args[1] == completeCommand {
+		return c.runComplete(os.Args[2:])
+	}
+	if len(c.middlewares) > 0 {
+		defer c.wrapTarget(os.Args[1:])()
+	}
+	marker := parseMarker(c.app.RootNode)
+	if marker.IsValid() {
+		marker.SetZero()
+	}
+	if err := c.app.Run(); err != nil {
+		if marker.IsValid() && marker.IsZero() {
+			err = usageError{err}
+		}
+		return c.handleError(err)
+	}
+	return nil
 }
 
 // AddCommand adds a dynamic command to the application.
@@ -77,10 +103,8 @@ func (c *Command) AddCommand(name string, cmd *parser.CommandNode) {
 
 // SetTranslator sets the translator for the application.
 func (c *Command) SetTranslator(tr help.Translator) {
+	c.translator = tr
 	c.app.SetTranslator(tr)
-	if c.manCmd != nil {
-		c.manCmd.translator = tr
-	}
 }
 
 // SetName sets the name of the root command.
@@ -115,32 +139,107 @@ func NewCommandFromStruct(s any) (*Command, error) {
 		return nil, err
 	}
 
+	node := app.RootNode
+
+	c := &Command{
+		Use:   node.Name,
+		Short: node.Description,
+		root:  s,
+		app:   app,
+	}
+
 	// We inject the man command
-	manCmd := &ManCmd{root: s}
-	manNode, err := parser.Parse("man", manCmd)
+	manNode, err := parser.Parse("man", &ManCmd{cmd: c})
 	if err == nil {
 		manNode.Description = "Generate man page"
 		app.AddCommand("man", manNode)
 	}
 
-	node := app.RootNode
+	// We inject the completion command
+	completionCmd := &CompletionCmd{cmd: c}
+	completionNode, err := parser.Parse("completion", completionCmd)
+	if err == nil {
+		if _, ok := node.Children["completion"]; !ok {
+			completionNode.Description = "Generate shell completion scripts"
+			app.AddCommand("completion", completionNode)
+		}
+	}
 
-	c := &Command{
-		Use:    node.Name,
-		Short:  node.Description,
-		root:   s,
-		app:    app,
-		manCmd: manCmd,
+	// We inject the help command
+	helpNode, err := parser.Parse("help", &HelpCmd{cmd: c})
+	if err == nil {
+		if _, ok := node.Children["help"]; !ok {
+			helpNode.Description = "Show the manual of a command or topic"
+			app.AddCommand("help", helpNode)
+		}
 	}
+
+	// We inject the global flags shared by every command
+	injectFlag(node, "output", "Output format: table, json, yaml or plain", reflect.ValueOf(&c.output).Elem())
+	injectFlag(node, "assume-yes", "Automatically answer yes to confirmations and use defaults for other prompts", reflect.ValueOf(&c.assumeYes).Elem())
+	injectFlag(node, "no-input", "Never prompt, use defaults or fail when an answer is required", reflect.ValueOf(&c.noInput).Elem())
+	injectFlag(node, "answers", "Read form answers from a JSON or YAML file instead of prompting", reflect.ValueOf(&c.answersFile).Elem())
+
 	return c, nil
 }
 
-// GenerateManPage generates a man page for the declarative struct
+// injectFlag adds a global flag to the root node, unless the root struct
+// already declares a flag with the same name. Injected flags are long-only
+// to avoid clashing with the shorthands declared by the application.
+func injectFlag(node *parser.CommandNode, name, description string, field reflect.Value) {
+	if _, ok := node.Flags[name]; ok {
+		return
+	}
+	node.Flags[name] = &parser.FlagMetadata{
+		Name:        name,
+		Description: description,
+		Field:       field,
+	}
+}
+
+// SetOutput sets the writer used to print data and tables, defaults to
+// os.Stdout.
+func (c *Command) SetOutput(w io.Writer) {
+	c.stdout = w
+}
+
+// SetErrOutput sets the writer used to print status lines, such as the
+// plain text fallback of spinners and progress bars, defaults to os.Stderr.
+func (c *Command) SetErrOutput(w io.Writer) {
+	c.stderr = w
+}
+
+// writer returns the writer used to print data and tables.
+func (c *Command) writer() io.Writer {
+	if c.stdout == nil {
+		return os.Stdout
+	}
+	return c.stdout
+}
+
+// errWriter returns the writer used to print status lines.
+func (c *Command) errWriter() io.Writer {
+	if c.stderr == nil {
+		return os.Stderr
+	}
+	return c.stderr
+}
+
+// GenerateManPage generates a man page for the declarative struct,
+// documenting the whole command tree in a single page. The EXIT STATUS,
+// ENVIRONMENT, FILES and SEE ALSO sections are filled from the man-exit,
+// man-env, man-files and man-see-also tags of the embedded cli.Base, entries
+// are separated by semicolons and names from descriptions by "=".
+//
+// Every description is passed to the translator, with the "pr:" prefix
+// removed, so both prefixed keys and plain English msgids are translated.
+// The page is named after the running program, filepath.Base(os.Args[0]),
+// instead of the "root" name of the parsed tree.
 //
 // Example:
 //
 //	type RootCmd struct {
-//		cli.Base
+//		cli.Base `man-exit:"0=Success;1=Failure" man-see-also:"apx(1)"`
 //		Poll PollCmd `cmd:"poll" help:"Ask the user preferred hero"`
 //		Man  ManCmd  `cmd:"man" help:"Generate man page"`
 //	}
@@ -153,66 +252,52 @@ func NewCommandFromStruct(s any) (*Command, error) {
 // GenerateManPage automatically uses a zero-value instance of the root struct
 // to exclude any dynamic commands.
 func GenerateManPage(root any, tr help.Translator) (string, error) {
-	t := reflect.TypeOf(root)
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
-	}
-	cleanRoot := reflect.New(t).Interface()
-
-	node, err := parser.Parse("root", cleanRoot)
+	node, err := cleanNode(root)
 	if err != nil {
 		return "", err
 	}
 
-	description := node.Description
-	if tr != nil {
-		description = tr(cleanKey(description))
+	translate := func(s string) string {
+		if tr == nil {
+			return cleanKey(s)
+		}
+		return tr(cleanKey(s))
 	}
+	pages := buildDocPages(node, filepath.Base(os.Args[0]), nil, nil, translate, docDate())
+	return renderManPage(pages, 1, manLabels{tr: tr}), nil
+}
 
-	d := roff.NewDocument()
-	d.Heading(1, node.Name, description, time.Now())
-
-	docNode(d, node, tr)
-
-	return d.String(), nil
+// ManPage generates the man page of the command like GenerateManPage, also
+// documenting the global flags and the injected commands.
+//
+// Example:
+//
+//	man, err := myApp.CLI.ManPage()
+func (c *Command) ManPage() (string, error) {
+	return c.LocalizedManPage(c.language, c.translator)
 }
 
-// docNode recursively documents a command node and its children.
-func docNode(d *roff.Document, node *parser.CommandNode, tr help.Translator) {
-	description := node.Description
-	if tr != nil {
-		description = tr(cleanKey(description))
+// cleanNode parses a zero-value instance of the root struct, so that
+// dynamic commands are excluded.
+func cleanNode(root any) (*parser.CommandNode, error) {
+	t := reflect.TypeOf(root)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
+	return parser.Parse("root", reflect.New(t).Interface())
+}
 
-	d.Section("subcommand " + node.Name)
-	d.Indent(4)
-	d.Text(description)
-	d.IndentEnd()
-	d.EndSection()
-
-	// Options
-	if len(node.Flags) > 0 {
-		d.SubSection("Options")
-		for name, meta := range node.Flags {
-			short := ""
-			if meta.Short != "" {
-				short = fmt.Sprintf("-%s, ", meta.Short)
-			}
-			desc := meta.Description
-			if tr != nil {
-				desc = tr(cleanKey(desc))
-			}
-			d.Text(fmt.Sprintf("  %s--%s  %s\n", short, name, desc))
-		}
-		d.EndSection()
-	}
+// translate resolves "pr:" prefixed keys using the command translator.
+func (c *Command) translate(s string) string {
+	return translateWith(c.translator, s)
+}
 
-	// Commands
-	if len(node.Children) > 0 {
-		for _, child := range node.Children {
-			docNode(d, child, tr)
-		}
+// translateWith resolves "pr:" prefixed keys using the given translator.
+func translateWith(tr help.Translator, s string) string {
+	if tr != nil && strings.HasPrefix(s, "pr:") {
+		return tr(cleanKey(s))
 	}
+	return cleanKey(s)
 }
 
 func cleanKey(key string) string {
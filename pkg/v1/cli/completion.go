@@ -0,0 +1,337 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Shell completion scripts and runtime completion.
+*/
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/mirkobrombin/go-cli-builder/v2/pkg/parser"
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+)
+
+// completeCommand is the hidden command the completion scripts call to get
+// the suggestions for the command line being typed.
+const completeCommand = "__complete"
+
+// Completer is implemented by commands which suggest values at runtime.
+// Complete is called with the name of the flag whose value is being
+// completed, or an empty string for positional arguments, the positional
+// arguments typed so far and the word being completed. Suggestions may
+// carry a description separated by a tab.
+//
+// Example:
+//
+//	func (c *FormatCmd) Complete(flag string, args []string, toComplete string) []string {
+//		disks, err := fs.GetDiskList()
+//		if err != nil {
+//			return nil
+//		}
+//		var paths []string
+//		for _, disk := range disks {
+//			paths = append(paths, disk.Path+"\t"+disk.Label)
+//		}
+//		return paths
+//	}
+type Completer interface {
+	Complete(flag string, args []string, toComplete string) []string
+}
+
+// CompletionCmd is the command to generate shell completion scripts
+type CompletionCmd struct {
+	Base
+	Shell string `arg:"" help:"Shell to generate the completion for: bash, zsh or fish" required:"true"`
+
+	cmd *Command
+}
+
+// Run runs the completion command
+func (c *CompletionCmd) Run() error {
+	return c.cmd.GenerateCompletion(c.cmd.writer(), c.Shell)
+}
+
+// completionShells lists the shells supported by GenerateCompletion.
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// GenerateCompletion writes the completion script for the given shell,
+// one of bash, zsh or fish. The scripts call the program back to get the
+// suggestions, so subcommands, flags and dynamic values are always up to
+// date.
+//
+// The script can be loaded in the current shell with:
+//
+//	source <(myapp completion bash)
+//	myapp completion fish | source
+//
+// Example:
+//
+//	err := myApp.CLI.GenerateCompletion(os.Stdout, "zsh")
+func (c *Command) GenerateCompletion(w io.Writer, shell string) error {
+	name := c.programName()
+	fn := strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' {
+			return '_'
+		}
+		return r
+	}, name)
+
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion
+	case "zsh":
+		script = zshCompletion
+	case "fish":
+		script = fishCompletion
+	default:
+		return fmt.Errorf("unsupported shell %q, expected one of %s", shell, strings.Join(completionShells, ", "))
+	}
+
+	r := strings.NewReplacer("{{name}}", name, "{{fn}}", fn, "{{complete}}", completeCommand)
+	_, err := io.WriteString(w, r.Replace(script))
+	return err
+}
+
+// programName returns the name the program is invoked with.
+func (c *Command) programName() string {
+	if c.app.RootNode.Name != "" && c.app.RootNode.Name != "root" {
+		return c.app.RootNode.Name
+	}
+	return filepath.Base(os.Args[0])
+}
+
+// Complete returns the suggestions for the given command line, excluding
+// the program name. The last word is the one being completed and can be
+// empty. Each suggestion may carry a description separated by a tab.
+//
+// Example:
+//
+//	suggestions := myApp.CLI.Complete([]string{"poll", "--he"})
+func (c *Command) Complete(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	toComplete := words[len(words)-1]
+
+	node := c.app.RootNode
+	flags := map[string]*parser.FlagMetadata{}
+	for name, meta := range node.Flags {
+		flags[name] = meta
+	}
+
+	var args []string
+	var pendingFlag string
+	for _, word := range words[:len(words)-1] {
+		if pendingFlag != "" {
+			pendingFlag = ""
+			continue
+		}
+
+		if strings.HasPrefix(word, "-") && word != "-" {
+			if name := flagName(word, flags); name != "" && !strings.Contains(word, "=") &&
+				flags[name].Field.Kind() != reflect.Bool {
+				pendingFlag = name
+			}
+			continue
+		}
+
+		if child, ok := node.Children[word]; ok && len(args) == 0 {
+			node = child
+			for name, meta := range node.Flags {
+				flags[name] = meta
+			}
+			continue
+		}
+		args = append(args, word)
+	}
+
+	// --flag=value
+	if strings.HasPrefix(toComplete, "--") && strings.Contains(toComplete, "=") {
+		name, value, _ := strings.Cut(toComplete[2:], "=")
+		var suggestions []string
+		for _, s := range c.completeFlagValue(node, name, args, value) {
+			suggestions = append(suggestions, "--"+name+"="+s)
+		}
+		return suggestions
+	}
+
+	if pendingFlag != "" {
+		return c.completeFlagValue(node, pendingFlag, args, toComplete)
+	}
+
+	var suggestions []string
+	if strings.HasPrefix(toComplete, "-") {
+		for _, name := range sortedKeys(flags) {
+			meta := flags[name]
+			suggestions = append(suggestions, "--"+name+"\t"+c.translate(meta.Description))
+			if meta.Short != "" {
+				suggestions = append(suggestions, "-"+meta.Short+"\t"+c.translate(meta.Description))
+			}
+		}
+		return filterPrefix(suggestions, toComplete)
+	}
+
+	if len(args) == 0 {
+		for _, name := range sortedKeys(node.Children) {
+			suggestions = append(suggestions, name+"\t"+c.translate(node.Children[name].Description))
+		}
+	}
+	if node.Type == reflect.TypeOf(CompletionCmd{}) {
+		suggestions = append(suggestions, completionShells...)
+	}
+	if node.Type == reflect.TypeOf(HelpCmd{}) {
+		suggestions = append(suggestions, c.helpCompletions(args)...)
+	}
+	if completer, ok := nodeCompleter(node); ok {
+		suggestions = append(suggestions, completer.Complete("", args, toComplete)...)
+	}
+	return filterPrefix(suggestions, toComplete)
+}
+
+// completeFlagValue returns the suggestions for the value of a flag.
+func (c *Command) completeFlagValue(node *parser.CommandNode, flag string, args []string, toComplete string) []string {
+	var suggestions []string
+	if meta := c.app.RootNode.Flags[flag]; meta != nil && meta.Field.CanAddr() &&
+		meta.Field.Addr().Interface() == any(&c.output) {
+		for _, format := range types.OutputFormats {
+			suggestions = append(suggestions, string(format))
+		}
+	}
+	if completer, ok := nodeCompleter(node); ok {
+		suggestions = append(suggestions, completer.Complete(flag, args, toComplete)...)
+	}
+	return filterPrefix(suggestions, toComplete)
+}
+
+// flagName resolves a --long or -s word to the name of a known flag.
+func flagName(word string, flags map[string]*parser.FlagMetadata) string {
+	name, _, _ := strings.Cut(strings.TrimLeft(word, "-"), "=")
+	if _, ok := flags[name]; ok && strings.HasPrefix(word, "--") {
+		return name
+	}
+	for long, meta := range flags {
+		if meta.Short != "" && meta.Short == name {
+			return long
+		}
+	}
+	return ""
+}
+
+// nodeCompleter returns the Completer implemented by the command of a node.
+func nodeCompleter(node *parser.CommandNode) (Completer, bool) {
+	if completer, ok := node.Value.Interface().(Completer); ok {
+		return completer, true
+	}
+	if node.Value.CanAddr() {
+		completer, ok := node.Value.Addr().Interface().(Completer)
+		return completer, ok
+	}
+	return nil, false
+}
+
+// filterPrefix keeps the suggestions starting with prefix, descriptions
+// excluded.
+func filterPrefix(suggestions []string, prefix string) []string {
+	filtered := []string{}
+	for _, s := range suggestions {
+		value, _, _ := strings.Cut(s, "\t")
+		if strings.HasPrefix(value, prefix) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// runComplete prints the suggestions for the words following the hidden
+// completion command, one per line.
+func (c *Command) runComplete(words []string) error {
+	w := c.writer()
+	for _, s := range c.Complete(words) {
+		if _, err := fmt.Fprintln(w, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const bashCompletion = `# bash completion for {{name}}
+_{{fn}}_completions() {
+    local cur words cword
+    # COMP_WORDS splits --flag=value at "=", keep it as a single word
+    if declare -F _get_comp_words_by_ref >/dev/null; then
+        _get_comp_words_by_ref -n =: cur words cword
+    else
+        read -ra words <<< "${COMP_LINE:0:COMP_POINT}"
+        [[ "${COMP_LINE:COMP_POINT-1:1}" == [[:space:]] ]] && words+=("")
+        cword=$(( ${#words[@]} - 1 ))
+        cur="${words[cword]}"
+    fi
+    local IFS=$'\n'
+    local out
+    out=$("${words[0]}" {{complete}} "${words[@]:1:cword}" 2>/dev/null | cut -f1)
+    COMPREPLY=($(compgen -W "${out}" -- "${cur}"))
+    # readline only replaces the text after the last word break
+    local breaks="${COMP_WORDBREAKS//[^=:]/}"
+    if [[ -n "${breaks}" && "${cur}" == *["${breaks}"]* ]]; then
+        local prefix="${cur%"${cur##*["${breaks}"]}"}"
+        COMPREPLY=("${COMPREPLY[@]#"${prefix}"}")
+    fi
+}
+complete -o default -F _{{fn}}_completions {{name}}
+`
+
+const zshCompletion = `#compdef {{name}}
+# zsh completion for {{name}}
+_{{fn}}() {
+    local -a completions
+    local line description
+    for line in "${(@f)$(${words[1]} {{complete}} "${(@)words[2,$CURRENT]}" 2>/dev/null)}"; do
+        [[ -z "${line}" ]] && continue
+        description=""
+        [[ "${line}" == *$'\t'* ]] && description="${line#*$'\t'}"
+        completions+=("${${line%%$'\t'*}//:/\\:}:${description}")
+    done
+    if (( ${#completions} )); then
+        _describe 'values' completions
+    else
+        _files
+    fi
+}
+
+if [ "${funcstack[1]}" = "_{{fn}}" ]; then
+    _{{fn}} "$@"
+else
+    compdef _{{fn}} {{name}}
+fi
+`
+
+const fishCompletion = `# fish completion for {{name}}
+function __{{fn}}_complete
+    set -l args (commandline -opc)
+    set -e args[1]
+    {{name}} {{complete}} $args (commandline -ct) 2>/dev/null
+end
+
+complete -c {{name}} -f -n 'test -n "$(__{{fn}}_complete)"' -a '(__{{fn}}_complete)'
+complete -c {{name}} -F -n 'test -z "$(__{{fn}}_complete)"'
+`
@@ -0,0 +1,99 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Colorized rendering of line diffs.
+*/
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	fstypes "github.com/vanilla-os/sdk/pkg/v1/fs/types"
+)
+
+var (
+	diffFileStyle    = lipgloss.NewStyle().Bold(true)
+	diffHunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	diffGutterStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+// Diff prints a line diff, as returned by fs.GetLineDiff. On a terminal the
+// lines are colorized and numbered, otherwise the standard unified format
+// is printed so that the output can be piped to patch.
+//
+// Example:
+//
+//	diff, err := fs.GetLineDiff("/etc/hosts.orig", "/etc/hosts", fs.DefaultDiffContext)
+//	if err != nil {
+//		return err
+//	}
+//	return myApp.CLI.Diff(diff)
+func (c *Command) Diff(diff fstypes.LineDiff) error {
+	return RenderDiff(c.writer(), diff, isTerminal(c.writer()))
+}
+
+// RenderDiff writes a line diff to w. When color is false the standard
+// unified format is written, otherwise added and removed lines are
+// colorized and prefixed with their old and new line numbers.
+//
+// Example:
+//
+//	diff := fs.DiffText("a/motd", "b/motd", oldMotd, newMotd, fs.DefaultDiffContext)
+//	err := cli.RenderDiff(os.Stdout, diff, true)
+func RenderDiff(w io.Writer, diff fstypes.LineDiff, color bool) error {
+	if !color || diff.Equal || diff.Binary {
+		_, err := io.WriteString(w, diff.Unified())
+		return err
+	}
+
+	width := len(strconv.Itoa(lastLine(diff)))
+	number := func(n int) string {
+		if n == 0 {
+			return strings.Repeat(" ", width)
+		}
+		return fmt.Sprintf("%*d", width, n)
+	}
+
+	var b strings.Builder
+	b.WriteString(diffFileStyle.Render("--- "+diff.OldName) + "\n")
+	b.WriteString(diffFileStyle.Render("+++ "+diff.NewName) + "\n")
+	for _, h := range diff.Hunks {
+		b.WriteString(diffHunkStyle.Render(h.Header()) + "\n")
+		for _, line := range h.Lines {
+			gutter := diffGutterStyle.Render(number(line.OldLine) + " " + number(line.NewLine) + " │")
+			text := string(line.Kind) + strings.TrimSuffix(line.Text, "\n")
+			switch line.Kind {
+			case fstypes.DiffAdded:
+				text = diffAddedStyle.Render(text)
+			case fstypes.DiffRemoved:
+				text = diffRemovedStyle.Render(text)
+			}
+			b.WriteString(gutter + " " + text + "\n")
+			if !strings.HasSuffix(line.Text, "\n") {
+				b.WriteString(diffGutterStyle.Render(fstypes.NoNewlineMarker) + "\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// lastLine returns the highest line number in a diff, used to align the
+// line numbers.
+func lastLine(diff fstypes.LineDiff) int {
+	n := 0
+	for _, h := range diff.Hunks {
+		n = max(n, h.OldStart+h.OldLines, h.NewStart+h.NewLines)
+	}
+	return n
+}
@@ -0,0 +1,354 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Documentation generation for the command tree.
+*/
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mirkobrombin/go-cli-builder/v2/pkg/parser"
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+)
+
+// DocRenderer renders the documentation page of a command in a specific
+// format, such as roff, Markdown or HTML.
+type DocRenderer interface {
+	// Extension returns the extension of the generated files, e.g. ".md"
+	Extension() string
+
+	// Render writes the page of a single command
+	Render(w io.Writer, page *types.DocPage) error
+}
+
+// ExampleProvider is implemented by commands which want to show usage
+// examples in their documentation.
+//
+// Example:
+//
+//	func (c *PollCmd) Examples() []string {
+//		return []string{
+//			"myapp poll",
+//			"myapp poll --hero Batman",
+//		}
+//	}
+type ExampleProvider interface {
+	Examples() []string
+}
+
+// DocPages returns the documentation pages of the command and all its
+// subcommands, the root command first.
+//
+// Example:
+//
+//	for _, page := range myApp.CLI.DocPages() {
+//		fmt.Println(page.Name, page.Description)
+//	}
+func (c *Command) DocPages() []*types.DocPage {
+	root := c.app.RootNode
+	pages := buildDocPages(root, c.programName(), nil, nil, c.translate, docDate())
+	if len(pages) > 0 {
+		if pages[0].Description == "" {
+			pages[0].Description = c.translate(c.Short)
+		}
+		pages[0].Long = c.translate(c.Long)
+	}
+	return pages
+}
+
+// RenderDoc writes the page of the command at the given path, e.g.
+// RenderDoc(w, r, "config", "set"); no path renders the root page.
+//
+// Example:
+//
+//	err := myApp.CLI.RenderDoc(os.Stdout, &cli.MarkdownRenderer{}, "poll")
+func (c *Command) RenderDoc(w io.Writer, r DocRenderer, path ...string) error {
+	name := strings.Join(append([]string{c.programName()}, path...), " ")
+	for _, page := range c.DocPages() {
+		if page.Name == name {
+			return r.Render(w, page)
+		}
+	}
+	return fmt.Errorf("unknown command %q", name)
+}
+
+// GenerateDocs writes one file per command into dir, creating it if
+// needed, and returns the paths of the generated files.
+//
+// Example:
+//
+//	files, err := myApp.CLI.GenerateDocs(&cli.HTMLRenderer{}, "docs/reference")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Generated %d pages\n", len(files))
+func (c *Command) GenerateDocs(r DocRenderer, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, page := range c.DocPages() {
+		path := filepath.Join(dir, page.FileName+r.Extension())
+		f, err := os.Create(path)
+		if err != nil {
+			return files, err
+		}
+		err = r.Render(f, page)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return files, fmt.Errorf("cannot render %s: %w", path, err)
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// buildDocPages documents a node and, recursively, its children.
+func buildDocPages(node *parser.CommandNode, name string, parent *types.DocPage, inherited []types.DocFlag, translate func(string) string, date time.Time) []*types.DocPage {
+	page := &types.DocPage{
+		Name:           name,
+		FileName:       strings.ReplaceAll(name, " ", "-"),
+		Program:        strings.SplitN(name, " ", 2)[0],
+		Description:    translate(node.Description),
+		Aliases:        node.Aliases,
+		Args:           docArgs(node, translate),
+		Flags:          docFlags(node.Flags, translate),
+		InheritedFlags: inherited,
+		Date:           date,
+	}
+	if parent != nil {
+		page.Parent = &types.DocLink{
+			Name:        parent.Name,
+			FileName:    parent.FileName,
+			Description: parent.Description,
+		}
+	}
+	if examples, ok := nodeExamples(node); ok {
+		page.Examples = examples.Examples()
+	}
+	docSections(node, page, translate)
+
+	usage := []string{name}
+	if len(page.Flags)+len(inherited) > 0 {
+		usage = append(usage, "[flags]")
+	}
+	if len(node.Children) > 0 {
+		usage = append(usage, "[command]")
+	}
+	for _, arg := range page.Args {
+		usage = append(usage, argUsage(arg))
+	}
+	page.Usage = strings.Join(usage, " ")
+
+	// children are indexed by name and by alias, only the name is kept
+	var children []string
+	for key, child := range node.Children {
+		if key == child.Name {
+			children = append(children, key)
+		}
+	}
+	slices.Sort(children)
+
+	childInherited := append(slices.Clone(inherited), page.Flags...)
+	pages := []*types.DocPage{page}
+	for _, key := range children {
+		childPages := buildDocPages(node.Children[key], name+" "+key, page, childInherited, translate, date)
+		page.Subcommands = append(page.Subcommands, types.DocLink{
+			Name:        childPages[0].Name,
+			FileName:    childPages[0].FileName,
+			Description: childPages[0].Description,
+		})
+		pages = append(pages, childPages...)
+	}
+	return pages
+}
+
+// docFlags documents flags sorted by name.
+func docFlags(flags map[string]*parser.FlagMetadata, translate func(string) string) []types.DocFlag {
+	var docs []types.DocFlag
+	for _, name := range sortedKeys(flags) {
+		meta := flags[name]
+		docs = append(docs, types.DocFlag{
+			Name:        name,
+			Short:       meta.Short,
+			Description: translate(meta.Description),
+			Default:     meta.Default,
+			Env:         meta.Env,
+			Required:    meta.Required,
+			Boolean:     meta.Field.IsValid() && meta.Field.Kind() == reflect.Bool,
+		})
+	}
+	return docs
+}
+
+// docArgs documents the positional arguments, naming them after their name
+// tag or their field name since the parser does not keep it.
+func docArgs(node *parser.CommandNode, translate func(string) string) []types.DocArg {
+	var names []string
+	if node.Value.IsValid() && node.Value.Kind() == reflect.Struct {
+		t := node.Value.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if _, ok := f.Tag.Lookup("arg"); !ok || f.Tag.Get("internal") == "ignore" {
+				continue
+			}
+			if n := f.Tag.Get("name"); n != "" {
+				names = append(names, n)
+			} else {
+				names = append(names, strings.ToLower(f.Name))
+			}
+		}
+	}
+
+	var docs []types.DocArg
+	for i, meta := range node.Args {
+		name := "arg" + strconv.Itoa(i+1)
+		if i < len(names) {
+			name = names[i]
+		}
+		docs = append(docs, types.DocArg{
+			Name:        name,
+			Description: translate(meta.Description),
+			Required:    meta.Required,
+			Variadic:    meta.IsGreedy,
+		})
+	}
+	return docs
+}
+
+// argUsage formats an argument for the usage line.
+func argUsage(arg types.DocArg) string {
+	s := "<" + arg.Name + ">"
+	if arg.Variadic {
+		s += "..."
+	}
+	if !arg.Required {
+		s = "[" + s + "]"
+	}
+	return s
+}
+
+// flagUsage formats a flag with its shorthand, e.g. "-t, --target <value>".
+func flagUsage(flag types.DocFlag) string {
+	s := "--" + flag.Name
+	if flag.Short != "" {
+		s = "-" + flag.Short + ", " + s
+	}
+	if !flag.Boolean {
+		s += " <value>"
+	}
+	return s
+}
+
+// flagDetails lists the default value, environment variable and required
+// state of a flag, if any.
+func flagDetails(flag types.DocFlag) []string {
+	var details []string
+	if flag.Required {
+		details = append(details, "required")
+	}
+	if flag.Default != "" {
+		details = append(details, "default: "+flag.Default)
+	}
+	if flag.Env != "" {
+		details = append(details, "env: "+flag.Env)
+	}
+	return details
+}
+
+// docSections fills the standard man page sections from the man-* tags of
+// the cli.Base embedded in the command, and the environment from the flags
+// bound to a variable.
+//
+// Entries are separated by semicolons, names and descriptions by "=":
+//
+//	type RootCmd struct {
+//		cli.Base `man-exit:"0=Success;1=Failure" man-files:"/etc/myapp.yml=Configuration" man-see-also:"apx(1);https://docs.vanillaos.org"`
+//	}
+func docSections(node *parser.CommandNode, page *types.DocPage, translate func(string) string) {
+	if node.Type != nil && node.Type.Kind() == reflect.Struct {
+		for i := 0; i < node.Type.NumField(); i++ {
+			f := node.Type.Field(i)
+			if f.Type != reflect.TypeFor[Base]() {
+				continue
+			}
+			page.ExitCodes = docEntries(f.Tag.Get("man-exit"), translate)
+			page.Environment = docEntries(f.Tag.Get("man-env"), translate)
+			page.Files = docEntries(f.Tag.Get("man-files"), translate)
+			for _, ref := range strings.Split(f.Tag.Get("man-see-also"), ";") {
+				if ref = strings.TrimSpace(ref); ref != "" {
+					page.SeeAlso = append(page.SeeAlso, ref)
+				}
+			}
+		}
+	}
+
+	for _, flag := range page.Flags {
+		if flag.Env == "" || slices.ContainsFunc(page.Environment, func(e types.DocEntry) bool {
+			return e.Name == flag.Env
+		}) {
+			continue
+		}
+		page.Environment = append(page.Environment, types.DocEntry{
+			Name:        flag.Env,
+			Description: flag.Description,
+		})
+	}
+}
+
+// docEntries parses a "name=description;name=description" tag.
+func docEntries(tag string, translate func(string) string) []types.DocEntry {
+	var entries []types.DocEntry
+	for _, entry := range strings.Split(tag, ";") {
+		name, desc, _ := strings.Cut(entry, "=")
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		entries = append(entries, types.DocEntry{
+			Name:        name,
+			Description: translate(strings.TrimSpace(desc)),
+		})
+	}
+	return entries
+}
+
+// nodeExamples returns the ExampleProvider implemented by a node command.
+func nodeExamples(node *parser.CommandNode) (ExampleProvider, bool) {
+	if !node.Value.IsValid() {
+		return nil, false
+	}
+	if p, ok := node.Value.Interface().(ExampleProvider); ok {
+		return p, true
+	}
+	if node.Value.CanAddr() {
+		p, ok := node.Value.Addr().Interface().(ExampleProvider)
+		return p, ok
+	}
+	return nil, false
+}
+
+// docDate returns the date shown in the generated documentation, honoring
+// SOURCE_DATE_EPOCH for reproducible builds.
+func docDate() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if secs, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(secs, 0).UTC()
+		}
+	}
+	return time.Now()
+}
@@ -0,0 +1,153 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: HTML documentation renderer.
+*/
+
+import (
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+)
+
+// HTMLRenderer renders command pages as standalone HTML documents, linking
+// subcommands to their own pages.
+//
+// Example:
+//
+//	_, err := myApp.CLI.GenerateDocs(&cli.HTMLRenderer{Stylesheet: "style.css"}, "site/cli")
+type HTMLRenderer struct {
+	// Stylesheet is an optional URL of a stylesheet linked by every page
+	Stylesheet string
+}
+
+// Extension returns the extension of HTML files.
+func (r *HTMLRenderer) Extension() string {
+	return ".html"
+}
+
+// Render writes the HTML page of a command.
+func (r *HTMLRenderer) Render(w io.Writer, page *types.DocPage) error {
+	return htmlDocTemplate.Execute(w, struct {
+		*types.DocPage
+		Stylesheet string
+		Extension  string
+	}{page, r.Stylesheet, r.Extension()})
+}
+
+var htmlDocTemplate = template.Must(template.New("doc").Funcs(template.FuncMap{
+	"argUsage":  argUsage,
+	"flagUsage": flagUsage,
+	"details": func(flag types.DocFlag) string {
+		return strings.Join(flagDetails(flag), ", ")
+	},
+	"join": strings.Join,
+	"isURL": func(s string) bool {
+		return strings.Contains(s, "://")
+	},
+	"dict": func(kv ...any) map[string]any {
+		m := make(map[string]any, len(kv)/2)
+		for i := 0; i+1 < len(kv); i += 2 {
+			m[kv[i].(string)] = kv[i+1]
+		}
+		return m
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}}</title>
+{{- if .Stylesheet}}
+<link rel="stylesheet" href="{{.Stylesheet}}">
+{{- end}}
+</head>
+<body>
+<h1>{{.Name}}</h1>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- if .Long}}
+<p>{{.Long}}</p>
+{{- end}}
+<h2>Usage</h2>
+<pre><code>{{.Usage}}</code></pre>
+{{- if .Aliases}}
+<p>Aliases: <code>{{join .Aliases ", "}}</code></p>
+{{- end}}
+{{- if .Args}}
+<h2>Arguments</h2>
+<table>
+<thead><tr><th>Argument</th><th>Description</th><th>Required</th></tr></thead>
+<tbody>
+{{- range .Args}}
+<tr><td><code>{{argUsage .}}</code></td><td>{{.Description}}</td><td>{{if .Required}}yes{{else}}no{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- template "flags" (dict "Heading" "Flags" "Flags" .Flags)}}
+{{- template "flags" (dict "Heading" "Global Flags" "Flags" .InheritedFlags)}}
+{{- if .Subcommands}}
+<h2>Commands</h2>
+<table>
+<thead><tr><th>Command</th><th>Description</th></tr></thead>
+<tbody>
+{{- range .Subcommands}}
+<tr><td><a href="{{.FileName}}{{$.Extension}}">{{.Name}}</a></td><td>{{.Description}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .Examples}}
+<h2>Examples</h2>
+<pre><code>{{join .Examples "\n"}}</code></pre>
+{{- end}}
+{{- template "entries" (dict "Heading" "Exit Status" "Column" "Code" "Entries" .ExitCodes)}}
+{{- template "entries" (dict "Heading" "Environment" "Column" "Variable" "Entries" .Environment)}}
+{{- template "entries" (dict "Heading" "Files" "Column" "Path" "Entries" .Files)}}
+{{- if or .Parent .SeeAlso}}
+<h2>See Also</h2>
+<ul>
+{{- with .Parent}}
+<li><a href="{{.FileName}}{{$.Extension}}">{{.Name}}</a>{{if .Description}} - {{.Description}}{{end}}</li>
+{{- end}}
+{{- range .SeeAlso}}
+<li>{{if isURL .}}<a href="{{.}}">{{.}}</a>{{else}}{{.}}{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+{{define "entries"}}
+{{- if .Entries}}
+<h2>{{.Heading}}</h2>
+<table>
+<thead><tr><th>{{.Column}}</th><th>Description</th></tr></thead>
+<tbody>
+{{- range .Entries}}
+<tr><td><code>{{.Name}}</code></td><td>{{.Description}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- end}}
+{{define "flags"}}
+{{- if .Flags}}
+<h2>{{.Heading}}</h2>
+<table>
+<thead><tr><th>Flag</th><th>Description</th></tr></thead>
+<tbody>
+{{- range .Flags}}
+<tr><td><code>{{flagUsage .}}</code></td><td>{{.Description}}{{with details .}} ({{.}}){{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- end}}
+`))
@@ -0,0 +1,141 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Markdown documentation renderer.
+*/
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+)
+
+// MarkdownRenderer renders command pages as GitHub flavored Markdown,
+// linking subcommands to their own pages.
+//
+// Example:
+//
+//	_, err := myApp.CLI.GenerateDocs(&cli.MarkdownRenderer{}, "wiki/cli")
+type MarkdownRenderer struct{}
+
+// Extension returns the extension of Markdown files.
+func (r *MarkdownRenderer) Extension() string {
+	return ".md"
+}
+
+// Render writes the Markdown page of a command.
+func (r *MarkdownRenderer) Render(w io.Writer, page *types.DocPage) error {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# %s\n\n", page.Name)
+	if page.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", page.Description)
+	}
+	if page.Long != "" {
+		fmt.Fprintf(&b, "%s\n\n", page.Long)
+	}
+
+	fmt.Fprintf(&b, "## Usage\n\n```\n%s\n```\n\n", page.Usage)
+	if len(page.Aliases) > 0 {
+		fmt.Fprintf(&b, "Aliases: `%s`\n\n", strings.Join(page.Aliases, "`, `"))
+	}
+
+	if len(page.Args) > 0 {
+		b.WriteString("## Arguments\n\n| Argument | Description | Required |\n| --- | --- | --- |\n")
+		for _, arg := range page.Args {
+			required := "no"
+			if arg.Required {
+				required = "yes"
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", argUsage(arg), markdownCell(arg.Description), required)
+		}
+		b.WriteString("\n")
+	}
+
+	writeMarkdownFlags(&b, "Flags", page.Flags)
+	writeMarkdownFlags(&b, "Global Flags", page.InheritedFlags)
+
+	if len(page.Subcommands) > 0 {
+		b.WriteString("## Commands\n\n| Command | Description |\n| --- | --- |\n")
+		for _, sub := range page.Subcommands {
+			fmt.Fprintf(&b, "| [%s](%s%s) | %s |\n", sub.Name, sub.FileName, r.Extension(), markdownCell(sub.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(page.Examples) > 0 {
+		fmt.Fprintf(&b, "## Examples\n\n```sh\n%s\n```\n\n", strings.Join(page.Examples, "\n"))
+	}
+
+	writeMarkdownEntries(&b, "Exit Status", "Code", page.ExitCodes)
+	writeMarkdownEntries(&b, "Environment", "Variable", page.Environment)
+	writeMarkdownEntries(&b, "Files", "Path", page.Files)
+
+	if page.Parent != nil || len(page.SeeAlso) > 0 {
+		b.WriteString("## See Also\n\n")
+		if page.Parent != nil {
+			fmt.Fprintf(&b, "- [%s](%s%s)", page.Parent.Name, page.Parent.FileName, r.Extension())
+			if page.Parent.Description != "" {
+				fmt.Fprintf(&b, " - %s", page.Parent.Description)
+			}
+			b.WriteString("\n")
+		}
+		for _, item := range page.SeeAlso {
+			if strings.Contains(item, "://") {
+				fmt.Fprintf(&b, "- <%s>\n", item)
+			} else {
+				fmt.Fprintf(&b, "- %s\n", item)
+			}
+		}
+	}
+
+	_, err := w.Write(bytes.TrimRight(b.Bytes(), "\n"))
+	if err == nil {
+		_, err = io.WriteString(w, "\n")
+	}
+	return err
+}
+
+// writeMarkdownFlags writes a table of flags under the given heading.
+func writeMarkdownFlags(b *bytes.Buffer, heading string, flags []types.DocFlag) {
+	if len(flags) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## %s\n\n| Flag | Description |\n| --- | --- |\n", heading)
+	for _, flag := range flags {
+		desc := markdownCell(flag.Description)
+		if details := flagDetails(flag); len(details) > 0 {
+			desc += " (" + markdownCell(strings.Join(details, ", ")) + ")"
+		}
+		fmt.Fprintf(b, "| `%s` | %s |\n", flagUsage(flag), desc)
+	}
+	b.WriteString("\n")
+}
+
+// writeMarkdownEntries writes a table of name and description pairs under
+// the given heading.
+func writeMarkdownEntries(b *bytes.Buffer, heading, column string, entries []types.DocEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "## %s\n\n| %s | Description |\n| --- | --- |\n", heading, column)
+	for _, entry := range entries {
+		fmt.Fprintf(b, "| `%s` | %s |\n", entry.Name, markdownCell(entry.Description))
+	}
+	b.WriteString("\n")
+}
+
+// markdownCell escapes the characters breaking a table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
@@ -0,0 +1,242 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Roff (man page) documentation renderer.
+*/
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+	"github.com/vanilla-os/sdk/pkg/v1/roff"
+	rtypes "github.com/vanilla-os/sdk/pkg/v1/roff/types"
+)
+
+// RoffRenderer renders command pages as man pages, one per command.
+//
+// Example:
+//
+//	_, err := myApp.CLI.GenerateDocs(&cli.RoffRenderer{}, "man/man1")
+type RoffRenderer struct {
+	// Section is the manual section, defaults to 1
+	Section uint
+
+	// Language is used to localize the section headings, e.g. "it"
+	Language string
+}
+
+func (r *RoffRenderer) section() uint {
+	if r.Section == 0 {
+		return 1
+	}
+	return r.Section
+}
+
+// Extension returns the extension of man pages, i.e. the section number.
+func (r *RoffRenderer) Extension() string {
+	return fmt.Sprintf(".%d", r.section())
+}
+
+// Render writes the man page of a command.
+func (r *RoffRenderer) Render(w io.Writer, page *types.DocPage) error {
+	_, err := io.WriteString(w, renderManPage([]*types.DocPage{page}, r.section(), manLabels{lang: r.Language}))
+	return err
+}
+
+// renderManPage renders the first page as a man page. The following pages,
+// if any, are the subcommands documented in the COMMANDS section, which is
+// how GenerateManPage documents the whole tree in a single page.
+func renderManPage(pages []*types.DocPage, section uint, labels manLabels) string {
+	page := pages[0]
+	subpages := pages[1:]
+
+	d := roff.NewDocument()
+	d.Heading(section, page.FileName, page.Description, page.Date)
+
+	// indexers require a description in the NAME section
+	summary := page.Description
+	if summary == "" {
+		summary = page.Name
+	}
+	d.LocalizedName(labels.get(rtypes.SectionName), page.FileName, summary)
+
+	d.Section(labels.get(rtypes.SectionSynopsis))
+	manSynopsis(d, page)
+	d.EndSection()
+
+	description := page.Long
+	if description == "" {
+		description = page.Description
+	}
+	if description != "" {
+		d.Section(labels.get(rtypes.SectionDescription))
+		d.Text(description)
+		if len(page.Aliases) > 0 {
+			d.Paragraph()
+			d.Text(labels.get("Aliases") + ": " + strings.Join(page.Aliases, ", "))
+		}
+		d.EndSection()
+	}
+
+	if len(page.Args) > 0 {
+		d.Section(labels.get("Arguments"))
+		manArgs(d, page.Args)
+		d.EndSection()
+	}
+
+	if len(page.Flags) > 0 {
+		d.Section(labels.get(rtypes.SectionOptions))
+		manFlags(d, page.Flags)
+		d.EndSection()
+	}
+
+	if len(page.InheritedFlags) > 0 {
+		d.Section(labels.get("Global Options"))
+		manFlags(d, page.InheritedFlags)
+		d.EndSection()
+	}
+
+	if len(subpages) > 0 {
+		d.Section(labels.get(rtypes.SectionCommands))
+		for _, sub := range subpages {
+			d.SubSectionTitle(sub.Name)
+			manSynopsis(d, sub)
+			if sub.Description != "" {
+				d.Paragraph()
+				d.Text(sub.Description)
+			}
+			if len(sub.Aliases) > 0 {
+				d.Paragraph()
+				d.Text(labels.get("Aliases") + ": " + strings.Join(sub.Aliases, ", "))
+			}
+			manArgs(d, sub.Args)
+			manFlags(d, sub.Flags)
+			if len(sub.Examples) > 0 {
+				d.Paragraph()
+				d.Example(sub.Examples...)
+			}
+		}
+		d.EndSection()
+	} else if len(page.Subcommands) > 0 {
+		d.Section(labels.get(rtypes.SectionCommands))
+		for _, sub := range page.Subcommands {
+			d.TaggedParagraph(-1)
+			d.TextBold(sub.Name)
+			d.EndSection()
+			d.Text(sub.Description)
+			d.EndSection()
+		}
+	}
+
+	if len(page.Examples) > 0 {
+		d.Section(labels.get(rtypes.SectionExamples))
+		d.Example(page.Examples...)
+		d.EndSection()
+	}
+
+	if len(page.ExitCodes) > 0 {
+		d.Section(labels.get(rtypes.SectionExitStatus))
+		var rows [][]string
+		for _, code := range page.ExitCodes {
+			rows = append(rows, []string{code.Name, code.Description})
+		}
+		d.Table([]string{labels.get("Code"), labels.get("Meaning")}, rows)
+		d.EndSection()
+	}
+
+	environment := page.Environment
+	for _, sub := range subpages {
+		for _, env := range sub.Environment {
+			if !slices.ContainsFunc(environment, func(e types.DocEntry) bool { return e.Name == env.Name }) {
+				environment = append(environment, env)
+			}
+		}
+	}
+	manEntries(d, labels.get(rtypes.SectionEnvironment), environment)
+	manEntries(d, labels.get(rtypes.SectionFiles), page.Files)
+
+	var refs []rtypes.Reference
+	var links []string
+	if page.Parent != nil {
+		refs = append(refs, rtypes.Reference{Name: page.Parent.FileName, Section: fmt.Sprint(section)})
+	}
+	if len(subpages) == 0 {
+		for _, sub := range page.Subcommands {
+			refs = append(refs, rtypes.Reference{Name: sub.FileName, Section: fmt.Sprint(section)})
+		}
+	}
+	for _, item := range page.SeeAlso {
+		if ref, ok := roff.ParseReference(item); ok {
+			refs = append(refs, ref)
+		} else {
+			links = append(links, item)
+		}
+	}
+	if len(refs)+len(links) > 0 {
+		d.Section(labels.get(rtypes.SectionSeeAlso))
+		d.References(refs...)
+		for i, link := range links {
+			if i > 0 || len(refs) > 0 {
+				d.Paragraph()
+			}
+			d.Link(link, "")
+		}
+		d.EndSection()
+	}
+
+	return d.String()
+}
+
+// manSynopsis writes the synopsis of a command page.
+func manSynopsis(d *roff.Document, page *types.DocPage) {
+	d.Synopsis(page.Name, strings.Fields(strings.TrimPrefix(page.Usage, page.Name))...)
+}
+
+// manArgs writes a tagged paragraph per argument.
+func manArgs(d *roff.Document, args []types.DocArg) {
+	for _, arg := range args {
+		d.TaggedParagraph(-1)
+		d.TextBold(argUsage(arg))
+		d.EndSection()
+		d.Text(arg.Description)
+		d.EndSection()
+	}
+}
+
+// manFlags writes a tagged paragraph per flag.
+func manFlags(d *roff.Document, flags []types.DocFlag) {
+	for _, flag := range flags {
+		d.TaggedParagraph(-1)
+		d.TextBold(flagUsage(flag))
+		d.EndSection()
+		desc := flag.Description
+		if details := flagDetails(flag); len(details) > 0 {
+			desc += " (" + strings.Join(details, ", ") + ")"
+		}
+		d.Text(desc)
+		d.EndSection()
+	}
+}
+
+// manEntries writes a section listing name and description pairs.
+func manEntries(d *roff.Document, heading string, entries []types.DocEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	d.Section(heading)
+	for _, entry := range entries {
+		d.TaggedParagraph(-1)
+		d.TextBold(entry.Name)
+		d.EndSection()
+		d.Text(entry.Description)
+		d.EndSection()
+	}
+}
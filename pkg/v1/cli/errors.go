@@ -0,0 +1,265 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Typed errors, their rendering and exit codes.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mirkobrombin/go-cli-builder/v2/pkg/parser"
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+	"github.com/vanilla-os/sdk/pkg/v1/logs"
+)
+
+// causeStyle is used to render the underlying cause of an error.
+var causeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+// hintStyle is used to render the hint of an error.
+var hintStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+
+// ErrUsage is found in the chain of the errors returned by Execute when the
+// builder rejected the flags or arguments, before any command ran.
+var ErrUsage = errors.New("invalid flags or arguments")
+
+// usageError wraps an error returned by the builder while parsing the
+// command line, keeping its message and matching ErrUsage.
+type usageError struct {
+	error
+}
+
+// Unwrap returns the wrapped error and ErrUsage.
+func (e usageError) Unwrap() []error {
+	return []error{e.error, ErrUsage}
+}
+
+// Error is an error meant to be shown to the user. It carries the exit code
+// of the program, a message which can be a "pr:" translation key, an
+// optional hint on how to solve the problem and the underlying cause.
+//
+// Example:
+//
+//	func (c *InstallCmd) Run() error {
+//		if err := pkg.Install(c.Name); err != nil {
+//			return cli.WrapError(err, types.ExitUnavailable, "pr:vso.err.install").
+//				WithHint("pr:vso.hint.network")
+//		}
+//		return nil
+//	}
+type Error struct {
+	// Code is the exit code of the program
+	Code types.ExitCode
+
+	// Message is the message shown to the user, "pr:" keys are translated
+	Message string
+
+	// Hint suggests how to solve the problem, "pr:" keys are translated
+	Hint string
+
+	// Cause is the underlying error, if any
+	Cause error
+}
+
+// NewError returns an error with the given exit code and message.
+//
+// Example:
+//
+//	return cli.NewError(types.ExitConfig, "pr:vso.err.no_config").
+//		WithHint("Run 'batsignal init' to create one")
+func NewError(code types.ExitCode, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// WrapError returns an error with the given exit code and message, caused
+// by err.
+//
+// Example:
+//
+//	data, err := os.ReadFile(path)
+//	if err != nil {
+//		return cli.WrapError(err, types.ExitNoInput, "Cannot read the signal file")
+//	}
+func WrapError(err error, code types.ExitCode, message string) *Error {
+	return &Error{Code: code, Message: message, Cause: err}
+}
+
+// WithHint sets the hint of the error and returns it.
+func (e *Error) WithHint(hint string) *Error {
+	e.Hint = hint
+	return e
+}
+
+// WithCause sets the underlying cause of the error and returns it.
+func (e *Error) WithCause(err error) *Error {
+	e.Cause = err
+	return e
+}
+
+// Error returns the untranslated message of the error followed by its
+// cause.
+func (e *Error) Error() string {
+	message := cleanKey(e.Message)
+	switch {
+	case e.Cause == nil:
+		return message
+	case message == "":
+		return e.Cause.Error()
+	}
+	return message + ": " + e.Cause.Error()
+}
+
+// Unwrap returns the underlying cause of the error.
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// ExitCode returns the exit code matching err: the code of an Error found
+// in the chain, ExitUsage for ErrUsage and ErrNonInteractive, ExitNoPerm
+// and ExitNoInput for permission and not found errors, ExitInterrupted when
+// the context was canceled and ExitFailure for any other error.
+//
+// Example:
+//
+//	err := myApp.CLI.Execute()
+//	os.Exit(int(cli.ExitCode(err)))
+func ExitCode(err error) types.ExitCode {
+	if err == nil {
+		return types.ExitOK
+	}
+
+	var cliErr *Error
+	if errors.As(err, &cliErr) && cliErr.Code != types.ExitOK {
+		return cliErr.Code
+	}
+
+	switch {
+	case errors.Is(err, ErrUsage), errors.Is(err, ErrNonInteractive):
+		return types.ExitUsage
+	case errors.Is(err, ErrRootRequired), errors.Is(err, fs.ErrPermission):
+		return types.ExitNoPerm
+	case errors.Is(err, ErrContainerNotAllowed):
+		return types.ExitUnavailable
+	case errors.Is(err, fs.ErrNotExist):
+		return types.ExitNoInput
+	case errors.Is(err, context.Canceled):
+		return types.ExitInterrupted
+	}
+	return types.ExitFailure
+}
+
+// parseMarker returns the cli.Base embedded in the value of a node, which
+// the builder fills right after parsing the command line and before running
+// any hook, so that a zero Base after a failed run tells a usage error apart
+// from a command failure. The returned value is invalid if there is none.
+func parseMarker(node *parser.CommandNode) reflect.Value {
+	v := node.Value
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Field(i); f.Type() == reflect.TypeFor[Base]() && f.CanSet() {
+			return f
+		}
+	}
+	return reflect.Value{}
+}
+
+// SetLogger sets the logger used to record the errors returned by the
+// commands, their details are written to Logger.File.
+//
+// Example:
+//
+//	myApp.CLI.SetLogger(myApp.Log)
+func (c *Command) SetLogger(logger *logs.Logger) {
+	c.logger = logger
+}
+
+// ExecuteAndExit runs the command like Execute and terminates the program
+// with the exit code matching the returned error.
+//
+// Example:
+//
+//	func main() {
+//		// ... setup app ...
+//		myApp.CLI.ExecuteAndExit()
+//	}
+func (c *Command) ExecuteAndExit() {
+	os.Exit(int(ExitCode(c.Execute())))
+}
+
+// handleError turns the error returned by a command into an Error, renders
+// it to the error output and records it in the log file.
+func (c *Command) handleError(err error) *Error {
+	var cliErr *Error
+	if !errors.As(err, &cliErr) {
+		cliErr = &Error{Cause: err}
+	}
+	code := ExitCode(err)
+
+	if c.logger != nil {
+		c.logger.File.Error().
+			Err(err).
+			Int("exit_code", int(code)).
+			Strs("args", os.Args[1:]).
+			Msg(cleanKey(cliErr.Message))
+	}
+
+	// the builder already printed the error together with the help
+	if !errors.Is(err, ErrUsage) {
+		c.renderError(c.errWriter(), cliErr)
+	}
+
+	if cliErr != err || cliErr.Code == types.ExitOK {
+		return &Error{Code: code, Hint: cliErr.Hint, Cause: err}
+	}
+	return cliErr
+}
+
+// renderError writes the translated error to w, as JSON if the JSON output
+// format was requested.
+func (c *Command) renderError(w io.Writer, e *Error) {
+	message := c.translate(e.Message)
+	hint := c.translate(e.Hint)
+	cause := ""
+	if e.Cause != nil {
+		cause = e.Cause.Error()
+	}
+	if message == "" {
+		message, cause = cause, ""
+	}
+
+	if format, _ := ParseOutputFormat(c.output); format == types.OutputJSON {
+		RenderOutput(w, map[string]any{
+			"error": map[string]any{
+				"code":    int(ExitCode(e)),
+				"message": message,
+				"cause":   cause,
+				"hint":    hint,
+			},
+		}, types.OutputJSON)
+		return
+	}
+
+	labels := manLabels{tr: c.translator, lang: c.language}
+	fmt.Fprintf(w, "%s %s\n", errorStyle.Bold(true).Render(labels.get("Error")+":"), message)
+	if cause != "" {
+		fmt.Fprintln(w, causeStyle.Render("  "+cause))
+	}
+	if hint != "" {
+		fmt.Fprintf(w, "%s %s\n", hintStyle.Render(labels.get("Hint")+":"), hint)
+	}
+}
@@ -0,0 +1,838 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Declarative multi-step forms built from struct tags.
+*/
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// Form step types, used as value of the `form` struct tag.
+const (
+	FormText        = "text"
+	FormPassword    = "password"
+	FormSelect      = "select"
+	FormMultiSelect = "multiselect"
+	FormConfirm     = "confirm"
+)
+
+var (
+	validatorsMu sync.RWMutex
+	validators   = map[string]Validator{}
+)
+
+// RegisterValidator registers a named validator which can then be referenced
+// in the `validate` tag of form fields.
+//
+// Example:
+//
+//	cli.RegisterValidator("hostname", func(value string) error {
+//		if strings.ContainsAny(value, " _") {
+//			return fmt.Errorf("%q is not a valid hostname", value)
+//		}
+//		return nil
+//	})
+func RegisterValidator(name string, v Validator) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	validators[name] = v
+}
+
+// formField is a single step of a form.
+type formField struct {
+	name     string
+	key      string
+	kind     string
+	prompt   string
+	options  []string
+	labels   []string
+	def      string
+	rules    []string
+	cond     string
+	minCount int
+	maxCount int
+	confirm  bool
+	value    reflect.Value
+}
+
+// RunForm asks the user to fill the given struct pointer, one step per
+// field tagged with `form`. The user can go back to the previous step with
+// esc and a review screen is shown before confirming the answers.
+//
+// Supported tags:
+//
+//   - form: the step type, one of text, password, select, multiselect, confirm
+//   - prompt: the question, "pr:" keys are translated
+//   - options: comma separated options for select and multiselect steps,
+//     "pr:" keys are translated when shown while the field, the answer
+//     files and the conditions use the untranslated values
+//   - default: the default value
+//   - validate: comma separated rules: required, min=N and max=N (length),
+//     regex=EXPR (must be the last rule) or the name of a validator
+//     registered with RegisterValidator
+//   - min, max: the selection bounds of multiselect steps
+//   - confirm: "true" asks to type passwords twice
+//   - if: a condition on a previous field, e.g. "Encrypt", "!Encrypt",
+//     "Desktop=GNOME" or "Desktop!=GNOME"
+//   - answer: the key used in answer files, defaults to the json tag or to
+//     the lowercase field name
+//
+// Fields which already hold a value, e.g. because they were bound to a
+// command flag, are pre-filled. In non-interactive mode, or when an answers
+// file is passed with --answers, no prompt is shown: each field is filled
+// from the answers file, its current value or its default, and an error
+// wrapping ErrNonInteractive is returned if a required answer is missing.
+//
+// Example:
+//
+//	type Setup struct {
+//		Hostname   string   `form:"text" prompt:"Choose a hostname" default:"batcave" validate:"required,min=3"`
+//		Desktop    string   `form:"select" prompt:"Pick a desktop" options:"GNOME,KDE"`
+//		Extras     []string `form:"multiselect" prompt:"Extras" options:"Flatpak,Steam" max:"2"`
+//		Encrypt    bool     `form:"confirm" prompt:"Encrypt the disk?" default:"true"`
+//		Passphrase string   `form:"password" prompt:"Passphrase" confirm:"true" if:"Encrypt"`
+//	}
+//
+//	var setup Setup
+//	if err := myApp.CLI.RunForm(&setup); err != nil {
+//		return err
+//	}
+func (c *Command) RunForm(v any) error {
+	fields, err := c.parseForm(v)
+	if err != nil {
+		return err
+	}
+
+	if c.answersFile != "" || !c.Interactive() {
+		answers, err := loadAnswers(c.answersFile)
+		if err != nil {
+			return err
+		}
+		return runFormUnattended(fields, answers)
+	}
+
+	return c.runFormInteractive(fields)
+}
+
+// SetAnswersFile sets the file forms read their answers from, same as the
+// --answers flag.
+func (c *Command) SetAnswersFile(path string) {
+	c.answersFile = path
+}
+
+// parseForm builds the form steps from the tags of a struct pointer.
+func (c *Command) parseForm(v any) ([]*formField, error) {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form: expected a pointer to a struct, got %T", v)
+	}
+	val = val.Elem()
+	typ := val.Type()
+
+	var fields []*formField
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		kind, ok := sf.Tag.Lookup("form")
+		if !ok || !sf.IsExported() {
+			continue
+		}
+
+		f := &formField{
+			name:   sf.Name,
+			key:    strings.ToLower(sf.Name),
+			kind:   kind,
+			prompt: c.translate(sf.Tag.Get("prompt")),
+			def:    sf.Tag.Get("default"),
+			cond:   strings.TrimSpace(sf.Tag.Get("if")),
+			value:  val.Field(i),
+		}
+		if f.prompt == "" {
+			f.prompt = sf.Name
+		}
+		if jsonName := strings.Split(sf.Tag.Get("json"), ",")[0]; jsonName != "" && jsonName != "-" {
+			f.key = jsonName
+		}
+		if answer := sf.Tag.Get("answer"); answer != "" {
+			f.key = answer
+		}
+		if options := sf.Tag.Get("options"); options != "" {
+			for _, opt := range strings.Split(options, ",") {
+				opt = strings.TrimSpace(opt)
+				f.options = append(f.options, cleanKey(opt))
+				f.labels = append(f.labels, c.translate(opt))
+			}
+		}
+		if rules := sf.Tag.Get("validate"); rules != "" {
+			f.rules = splitRules(rules)
+		}
+		f.confirm = sf.Tag.Get("confirm") == "true"
+
+		var err error
+		if f.minCount, err = atoiTag(sf, "min"); err != nil {
+			return nil, err
+		}
+		if f.maxCount, err = atoiTag(sf, "max"); err != nil {
+			return nil, err
+		}
+
+		switch kind {
+		case FormText, FormPassword:
+		case FormSelect, FormMultiSelect:
+			if len(f.options) == 0 {
+				return nil, fmt.Errorf("form: field %s has no options", sf.Name)
+			}
+		case FormConfirm:
+			if f.value.Kind() != reflect.Bool {
+				return nil, fmt.Errorf("form: confirm field %s must be a bool", sf.Name)
+			}
+		default:
+			return nil, fmt.Errorf("form: unknown step type %q for field %s", kind, sf.Name)
+		}
+		if kind == FormMultiSelect && !isStringSlice(f.value) {
+			return nil, fmt.Errorf("form: multiselect field %s must be a []string", sf.Name)
+		}
+
+		fields = append(fields, f)
+	}
+
+	for _, f := range fields {
+		if f.cond != "" && findField(fields, conditionField(f.cond)) == nil {
+			return nil, fmt.Errorf("form: field %s depends on unknown field %q", f.name, conditionField(f.cond))
+		}
+	}
+
+	return fields, nil
+}
+
+// splitRules splits the validate tag, keeping the regex rule intact since
+// it may contain commas.
+func splitRules(tag string) []string {
+	var rules []string
+	for tag != "" {
+		tag = strings.TrimSpace(tag)
+		if strings.HasPrefix(tag, "regex=") {
+			rules = append(rules, tag)
+			break
+		}
+		rule, rest, _ := strings.Cut(tag, ",")
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+		tag = rest
+	}
+	return rules
+}
+
+func atoiTag(sf reflect.StructField, name string) (int, error) {
+	tag := sf.Tag.Get(name)
+	if tag == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(tag)
+	if err != nil {
+		return 0, fmt.Errorf("form: invalid %s tag for field %s: %w", name, sf.Name, err)
+	}
+	return n, nil
+}
+
+func isStringSlice(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String
+}
+
+func findField(fields []*formField, name string) *formField {
+	for _, f := range fields {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// conditionField returns the name of the field referenced by a condition.
+func conditionField(cond string) string {
+	cond = strings.TrimPrefix(cond, "!")
+	if name, _, ok := strings.Cut(cond, "="); ok {
+		return strings.TrimSuffix(name, "!")
+	}
+	return cond
+}
+
+// visible evaluates the condition of a field against the current values.
+func (f *formField) visible(fields []*formField) bool {
+	if f.cond == "" {
+		return true
+	}
+
+	cond := f.cond
+	if name, expected, ok := strings.Cut(cond, "="); ok {
+		negate := strings.HasSuffix(name, "!")
+		other := findField(fields, strings.TrimSuffix(name, "!"))
+		if other == nil || !other.visible(fields) {
+			return false
+		}
+		return (other.display(false) == expected) != negate
+	}
+
+	negate := strings.HasPrefix(cond, "!")
+	other := findField(fields, strings.TrimPrefix(cond, "!"))
+	if other == nil || !other.visible(fields) {
+		return false
+	}
+	return !other.value.IsZero() != negate
+}
+
+// required reports whether the field needs an explicit answer.
+func (f *formField) required() bool {
+	switch f.kind {
+	case FormConfirm:
+		return false
+	case FormSelect, FormPassword:
+		return true
+	case FormMultiSelect:
+		return f.minCount > 0
+	}
+	return slices.Contains(f.rules, "required")
+}
+
+// check validates a text or password answer against the field rules.
+func (f *formField) check(value string) error {
+	for _, rule := range f.rules {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if value == "" {
+				return fmt.Errorf("a value is required")
+			}
+		case "min", "max":
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("invalid %s rule for field %s", name, f.name)
+			}
+			length := len([]rune(value))
+			if name == "min" && length < n {
+				return fmt.Errorf("the value must be at least %d characters long", n)
+			}
+			if name == "max" && length > n {
+				return fmt.Errorf("the value must be at most %d characters long", n)
+			}
+		case "regex":
+			re, err := regexp.Compile(arg)
+			if err != nil {
+				return fmt.Errorf("invalid regex rule for field %s: %w", f.name, err)
+			}
+			if !re.MatchString(value) {
+				return fmt.Errorf("the value does not match the expected format")
+			}
+		default:
+			validatorsMu.RLock()
+			v, ok := validators[name]
+			validatorsMu.RUnlock()
+			if !ok {
+				return fmt.Errorf("unknown validator %q for field %s", name, f.name)
+			}
+			if err := v(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// set assigns an answer to the underlying struct field, validating it.
+func (f *formField) set(answer any) error {
+	switch f.kind {
+	case FormMultiSelect:
+		var values []string
+		switch a := answer.(type) {
+		case []string:
+			values = a
+		case []any:
+			for _, item := range a {
+				values = append(values, fmt.Sprint(item))
+			}
+		case string:
+			for _, item := range strings.Split(a, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					values = append(values, item)
+				}
+			}
+		default:
+			return fmt.Errorf("%s: expected a list, got %T", f.key, answer)
+		}
+		for _, item := range values {
+			if !slices.Contains(f.options, item) {
+				return fmt.Errorf("%s: %q is not one of %s", f.key, item, strings.Join(f.options, ", "))
+			}
+		}
+		if err := checkSelectionCount(len(values), f.minCount, f.maxCount); err != nil {
+			return fmt.Errorf("%s: %w", f.key, err)
+		}
+		s := reflect.MakeSlice(f.value.Type(), len(values), len(values))
+		for i, item := range values {
+			s.Index(i).SetString(item)
+		}
+		f.value.Set(s)
+		return nil
+	case FormSelect:
+		value := fmt.Sprint(answer)
+		if !slices.Contains(f.options, value) {
+			return fmt.Errorf("%s: %q is not one of %s", f.key, value, strings.Join(f.options, ", "))
+		}
+		return setScalar(f.value, value)
+	case FormConfirm:
+		if b, ok := answer.(bool); ok {
+			f.value.SetBool(b)
+			return nil
+		}
+		return setScalar(f.value, fmt.Sprint(answer))
+	}
+
+	value := fmt.Sprint(answer)
+	if err := f.check(value); err != nil {
+		return fmt.Errorf("%s: %w", f.key, err)
+	}
+	return setScalar(f.value, value)
+}
+
+// setScalar parses value into a string, bool or integer field.
+func setScalar(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	default:
+		return fmt.Errorf("unsupported form field type %s", field.Type())
+	}
+	return nil
+}
+
+// display formats the current value of the field, masking passwords if
+// mask is true.
+func (f *formField) display(mask bool) string {
+	switch {
+	case f.kind == FormPassword && mask:
+		if f.value.IsZero() {
+			return ""
+		}
+		return "••••••"
+	case f.kind == FormConfirm:
+		if f.value.Bool() {
+			return "Yes"
+		}
+		return "No"
+	case isStringSlice(f.value):
+		return strings.Join(f.value.Interface().([]string), ", ")
+	}
+	return fmt.Sprint(f.value.Interface())
+}
+
+// label formats the current value of the field for the user, showing the
+// translated options and masking passwords.
+func (f *formField) label() string {
+	switch f.kind {
+	case FormSelect:
+		return f.optionLabel(f.display(false))
+	case FormMultiSelect:
+		var labels []string
+		for _, value := range f.value.Interface().([]string) {
+			labels = append(labels, f.optionLabel(value))
+		}
+		return strings.Join(labels, ", ")
+	}
+	return f.display(true)
+}
+
+// optionLabel returns the translated label of an option.
+func (f *formField) optionLabel(value string) string {
+	if idx := slices.Index(f.options, value); idx >= 0 {
+		return f.labels[idx]
+	}
+	return value
+}
+
+// checkText validates a text answer, which must also be valid for the type
+// of the field, e.g. a number for integer fields.
+func (f *formField) checkText(value string) error {
+	if err := f.check(value); err != nil {
+		return err
+	}
+	if err := setScalar(reflect.New(f.value.Type()).Elem(), value); err != nil {
+		return fmt.Errorf("%q is not a valid %s", value, f.value.Kind())
+	}
+	return nil
+}
+
+// loadAnswers reads a JSON or YAML answers file, an empty path results in
+// no answers.
+func loadAnswers(path string) (map[string]any, error) {
+	answers := map[string]any{}
+	if path == "" {
+		return answers, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read answers file: %w", err)
+	}
+
+	// JSON is valid YAML, so a single decoder covers both formats
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("cannot parse answers file %s: %w", path, err)
+	}
+	return answers, nil
+}
+
+// runFormUnattended fills the form from the answers, the current values
+// and the defaults, in this order.
+func runFormUnattended(fields []*formField, answers map[string]any) error {
+	for _, f := range fields {
+		if !f.visible(fields) {
+			continue
+		}
+
+		if answer, ok := answers[f.key]; ok {
+			if err := f.set(answer); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !f.value.IsZero() {
+			if f.kind == FormText || f.kind == FormPassword {
+				if err := f.check(f.display(false)); err != nil {
+					return fmt.Errorf("%s: %w", f.key, err)
+				}
+			}
+			continue
+		}
+
+		if f.def != "" {
+			if err := f.set(f.def); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if f.required() {
+			return nonInteractiveError(f.prompt)
+		}
+	}
+	return nil
+}
+
+// runFormInteractive runs the steps one after the other, handling the back
+// navigation and the final review screen.
+func (c *Command) runFormInteractive(fields []*formField) error {
+	// fields holding a value since the beginning are considered answered
+	// when coming back from the review screen
+	answered := make(map[int]bool)
+	editing := false
+
+	next := func(from int) int {
+		for i := from; i < len(fields); i++ {
+			if !fields[i].visible(fields) {
+				continue
+			}
+			if editing && answered[i] {
+				continue
+			}
+			return i
+		}
+		return len(fields)
+	}
+
+	var history []int
+	current := next(0)
+	for {
+		if current >= len(fields) {
+			choice, err := runFormReview(fields)
+			if err != nil {
+				return err
+			}
+			switch {
+			case choice == reviewConfirm:
+				return nil
+			case choice == reviewBack:
+				if len(history) > 0 {
+					current = history[len(history)-1]
+					history = history[:len(history)-1]
+				}
+			default:
+				editing = true
+				current = choice
+				for len(history) > 0 && history[len(history)-1] >= choice {
+					history = history[:len(history)-1]
+				}
+			}
+			continue
+		}
+
+		position, total := 0, 0
+		for i, f := range fields {
+			if f.visible(fields) {
+				total++
+				if i <= current {
+					position++
+				}
+			}
+		}
+
+		back, err := c.runFormStep(fields[current], position, total)
+		if err != nil {
+			return err
+		}
+		if back {
+			if len(history) > 0 {
+				current = history[len(history)-1]
+				history = history[:len(history)-1]
+			}
+			continue
+		}
+
+		answered[current] = true
+		history = append(history, current)
+		current = next(current + 1)
+	}
+}
+
+// runFormStep runs the prompt of a single step, pre-filled with the current
+// value or the default, and stores the answer.
+func (c *Command) runFormStep(f *formField, position, total int) (bool, error) {
+	prompt := fmt.Sprintf("%d/%d · %s", position, total, f.prompt)
+
+	initial := f.def
+	if !f.value.IsZero() {
+		initial = f.display(false)
+	}
+
+	var model tea.Model
+	switch f.kind {
+	case FormText:
+		m := initialTextInputModel(prompt, f.def)
+		if !f.value.IsZero() {
+			m.textInput.SetValue(initial)
+		}
+		m.validate = f.checkText
+		m.allowBack = true
+		model = m
+	case FormPassword:
+		confirmPrompt := ""
+		if f.confirm {
+			confirmPrompt = fmt.Sprintf("%d/%d · Confirm: %s", position, total, f.prompt)
+		}
+		m := initialPasswordModel(prompt, confirmPrompt)
+		m.validate = func(value string) error {
+			if value == "" {
+				return fmt.Errorf("the password cannot be empty")
+			}
+			return f.check(value)
+		}
+		m.allowBack = true
+		model = m
+	case FormSelect:
+		m := initialListModel(prompt, f.labels)
+		if idx := slices.Index(f.options, initial); idx >= 0 {
+			m.list.Select(idx)
+		}
+		m.allowBack = true
+		model = m
+	case FormMultiSelect:
+		m := initialMultiSelectModel(prompt, f.labels, f.minCount, f.maxCount)
+		selected := f.value.Interface().([]string)
+		if f.value.IsZero() && f.def != "" {
+			selected = strings.Split(f.def, ",")
+		}
+		for _, item := range selected {
+			if idx := slices.Index(f.options, strings.TrimSpace(item)); idx >= 0 {
+				m.checked[idx] = true
+			}
+		}
+		m.allowBack = true
+		model = m
+	case FormConfirm:
+		choice := f.value.Bool()
+		if f.value.IsZero() && f.def != "" {
+			choice, _ = strconv.ParseBool(f.def)
+		}
+		m := initialConfirmModel(prompt, "Yes", "No", choice)
+		m.allowBack = true
+		model = m
+	}
+
+	result, err := tea.NewProgram(model).Run()
+	if err != nil {
+		return false, err
+	}
+
+	switch m := result.(type) {
+	case textInputModel:
+		if m.err != nil || m.back {
+			return m.back, m.err
+		}
+		return false, setScalar(f.value, m.submitted)
+	case listModel:
+		if m.err != nil || m.back {
+			return m.back, m.err
+		}
+		idx := slices.Index(f.labels, m.selected)
+		if idx < 0 {
+			return false, fmt.Errorf("interrupted")
+		}
+		return false, setScalar(f.value, f.options[idx])
+	case multiSelectModel:
+		if m.err != nil || m.back {
+			return m.back, m.err
+		}
+		selected := []string{}
+		for i, value := range f.options {
+			if m.checked[i] {
+				selected = append(selected, value)
+			}
+		}
+		return false, f.set(selected)
+	case confirmModel:
+		if m.err != nil || m.back {
+			return m.back, m.err
+		}
+		f.value.SetBool(m.choice)
+		return false, nil
+	}
+
+	return false, fmt.Errorf("could not retrieve form answer")
+}
+
+const (
+	reviewConfirm = -1
+	reviewBack    = -2
+)
+
+type reviewModel struct {
+	labels []string
+	values []string
+	steps  []int
+	cursor int
+	choice int
+	err    error
+}
+
+func (m reviewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+c":
+		m.err = fmt.Errorf("interrupted")
+		return m, tea.Quit
+	case "esc":
+		m.choice = reviewBack
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j", "tab":
+		if m.cursor < len(m.labels) {
+			m.cursor++
+		}
+	case "enter":
+		m.choice = reviewConfirm
+		if m.cursor < len(m.steps) {
+			m.choice = m.steps[m.cursor]
+		}
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m reviewModel) View() string {
+	var s strings.Builder
+	s.WriteString("\n" + lipgloss.NewStyle().Bold(true).Render("Review your answers") + "\n\n")
+
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	for i, label := range m.labels {
+		line := fmt.Sprintf("%s %s", labelStyle.Render(label+":"), m.values[i])
+		if i == m.cursor {
+			s.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+		} else {
+			s.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+
+	confirm := "Confirm"
+	if m.cursor == len(m.labels) {
+		s.WriteString("\n" + selectedItemStyle.Bold(true).Render("> "+confirm) + "\n")
+	} else {
+		s.WriteString("\n" + itemStyle.Render(confirm) + "\n")
+	}
+
+	s.WriteString(helpStyle.Render("enter: edit or confirm • esc: back") + "\n")
+	return s.String()
+}
+
+// runFormReview shows the answers and returns reviewConfirm, reviewBack
+// or the index of the field to edit.
+func runFormReview(fields []*formField) (int, error) {
+	m := reviewModel{}
+	for i, f := range fields {
+		if !f.visible(fields) {
+			continue
+		}
+		m.labels = append(m.labels, f.prompt)
+		m.values = append(m.values, f.label())
+		m.steps = append(m.steps, i)
+	}
+	m.cursor = len(m.labels)
+
+	result, err := tea.NewProgram(m).Run()
+	if err != nil {
+		return 0, err
+	}
+
+	if m, ok := result.(reviewModel); ok {
+		if m.err != nil {
+			return 0, m.err
+		}
+		return m.choice, nil
+	}
+
+	return 0, fmt.Errorf("could not retrieve form review")
+}
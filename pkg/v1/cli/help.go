@@ -0,0 +1,183 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Built-in manual viewer for commands and help topics.
+*/
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+	"github.com/vanilla-os/sdk/pkg/v1/roff"
+	rtypes "github.com/vanilla-os/sdk/pkg/v1/roff/types"
+)
+
+// defaultHelpWidth is the width used when the output is not a terminal.
+const defaultHelpWidth = 80
+
+// HelpCmd is the command to show the manual of a command or topic
+type HelpCmd struct {
+	Base
+	Topic []string `arg:"" help:"Command or topic to show the manual of"`
+
+	cmd *Command
+}
+
+// Run runs the help command
+func (c *HelpCmd) Run() error {
+	return c.cmd.ShowHelp(c.Topic...)
+}
+
+// AddHelpTopic registers an additional topic shown by `myapp help <name>`,
+// the source is a roff document, e.g. built with roff.NewDocument.
+//
+// Example:
+//
+//	d := roff.NewDocument()
+//	d.Heading(7, "myapp-config", "Configuration", time.Now())
+//	d.Section(types.SectionDescription)
+//	d.Text("The configuration is read from /etc/myapp.yml")
+//	d.EndSection()
+//	myApp.CLI.AddHelpTopic("config", d.String())
+func (c *Command) AddHelpTopic(name, source string) {
+	if c.helpTopics == nil {
+		c.helpTopics = make(map[string]string)
+	}
+	c.helpTopics[name] = source
+}
+
+// HelpSource returns the roff source shown by the help command: the whole
+// manual if no topic is given, the page of a command given its path, e.g.
+// "config set", or a topic registered with AddHelpTopic.
+//
+// Example:
+//
+//	man, err := myApp.CLI.HelpSource("poll")
+func (c *Command) HelpSource(topic ...string) (string, error) {
+	if len(topic) == 0 {
+		return c.ManPage()
+	}
+
+	if source, ok := c.helpTopics[strings.Join(topic, " ")]; ok {
+		return source, nil
+	}
+
+	var b bytes.Buffer
+	if err := c.RenderDoc(&b, &RoffRenderer{}, topic...); err != nil {
+		return "", fmt.Errorf("no help topic for %q", strings.Join(topic, " "))
+	}
+	return b.String(), nil
+}
+
+// ShowHelp renders the manual of a command or topic for the terminal,
+// without relying on man being installed. In interactive mode pages longer
+// than the terminal are shown in a pager.
+//
+// Example:
+//
+//	if err := myApp.CLI.ShowHelp("poll"); err != nil {
+//		return err
+//	}
+func (c *Command) ShowHelp(topic ...string) error {
+	source, err := c.HelpSource(topic...)
+	if err != nil {
+		return err
+	}
+
+	doc, err := roff.Parse(source)
+	if err != nil {
+		return fmt.Errorf("cannot parse manual: %w", err)
+	}
+
+	width, height := defaultHelpWidth, 0
+	if f, ok := c.writer().(*os.File); ok && isTerminal(f) {
+		if w, h, err := term.GetSize(f.Fd()); err == nil {
+			width, height = w, h
+		}
+	}
+
+	page := roff.RenderTerminal(doc, width)
+	if c.Interactive() && height > 0 && strings.Count(page, "\n") >= height {
+		_, err := tea.NewProgram(pagerModel{doc: doc}, tea.WithAltScreen(), tea.WithOutput(c.writer())).Run()
+		return err
+	}
+
+	_, err = fmt.Fprint(c.writer(), page)
+	return err
+}
+
+// helpCompletions suggests the subcommands of the command at the path
+// typed so far and, for the first word, the registered topics.
+func (c *Command) helpCompletions(path []string) []string {
+	node := c.app.RootNode
+	for _, name := range path {
+		child, ok := node.Children[name]
+		if !ok {
+			return nil
+		}
+		node = child
+	}
+
+	var suggestions []string
+	for _, name := range sortedKeys(node.Children) {
+		if child := node.Children[name]; child.Name == name {
+			suggestions = append(suggestions, name+"\t"+c.translate(child.Description))
+		}
+	}
+	if len(path) == 0 {
+		suggestions = append(suggestions, sortedKeys(c.helpTopics)...)
+	}
+	return suggestions
+}
+
+// pagerModel shows a rendered document in a scrollable viewport, wrapping
+// it again whenever the terminal is resized.
+type pagerModel struct {
+	doc      *rtypes.Node
+	viewport viewport.Model
+	ready    bool
+}
+
+func (m pagerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m pagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	case tea.WindowSizeMsg:
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-1)
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 1
+		}
+		m.viewport.SetContent(roff.RenderTerminal(m.doc, msg.Width))
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m pagerModel) View() string {
+	if !m.ready {
+		return ""
+	}
+	status := fmt.Sprintf("%3.f%% • ↑/↓ scroll • q quit", m.viewport.ScrollPercent()*100)
+	return m.viewport.View() + "\n" + helpStyle.Render(status)
+}
@@ -0,0 +1,57 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Non-interactive mode detection for prompts and progress.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrNonInteractive is returned by prompts which require an answer from the
+// user while the command is running in non-interactive mode.
+var ErrNonInteractive = errors.New("input required but running in non-interactive mode")
+
+// SetAssumeYes makes confirmations return true and other prompts return
+// their default value, same as the --assume-yes flag.
+func (c *Command) SetAssumeYes(assumeYes bool) {
+	c.assumeYes = assumeYes
+}
+
+// SetNoInput disables every prompt, same as the --no-input flag.
+func (c *Command) SetNoInput(noInput bool) {
+	c.noInput = noInput
+}
+
+// AssumeYes reports whether confirmations are automatically accepted.
+func (c *Command) AssumeYes() bool {
+	return c.assumeYes
+}
+
+// Interactive reports whether prompts can be shown to the user. It returns
+// false if --assume-yes or --no-input were passed or if stdin or stdout are
+// not attached to a terminal, e.g. in CI, pipes or `ssh -T` sessions.
+//
+// Example:
+//
+//	if !myApp.CLI.Interactive() {
+//		myApp.Log.Term.Info().Msg("Running unattended, using defaults")
+//	}
+func (c *Command) Interactive() bool {
+	if c.assumeYes || c.noInput {
+		return false
+	}
+	return isTerminal(os.Stdin) && isTerminal(c.writer())
+}
+
+// nonInteractiveError builds the error returned by a prompt which has no
+// default to fall back on.
+func nonInteractiveError(prompt string) error {
+	return fmt.Errorf("%w: %s", ErrNonInteractive, prompt)
+}
@@ -0,0 +1,310 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Localized man pages and their installation.
+*/
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mirkobrombin/go-cli-builder/v2/pkg/help"
+	rtypes "github.com/vanilla-os/sdk/pkg/v1/roff/types"
+)
+
+// manHeadings holds the built-in translations of the fixed strings of man
+// pages, indexed by language and English text.
+var manHeadings = map[string]map[string]string{
+	"it": {
+		rtypes.SectionName:        "NOME",
+		rtypes.SectionSynopsis:    "SINTASSI",
+		rtypes.SectionDescription: "DESCRIZIONE",
+		rtypes.SectionOptions:     "OPZIONI",
+		rtypes.SectionCommands:    "COMANDI",
+		rtypes.SectionExamples:    "ESEMPI",
+		rtypes.SectionExitStatus:  "STATO DI USCITA",
+		rtypes.SectionEnvironment: "AMBIENTE",
+		rtypes.SectionFiles:       "FILE",
+		rtypes.SectionSeeAlso:     "VEDERE ANCHE",
+		"Arguments":               "Argomenti",
+		"Global Options":          "Opzioni globali",
+		"Aliases":                 "Alias",
+		"Code":                    "Codice",
+		"Meaning":                 "Significato",
+	},
+	"de": {
+		rtypes.SectionName:        "BEZEICHNUNG",
+		rtypes.SectionSynopsis:    "ÜBERSICHT",
+		rtypes.SectionDescription: "BESCHREIBUNG",
+		rtypes.SectionOptions:     "OPTIONEN",
+		rtypes.SectionCommands:    "BEFEHLE",
+		rtypes.SectionExamples:    "BEISPIELE",
+		rtypes.SectionExitStatus:  "EXIT-STATUS",
+		rtypes.SectionEnvironment: "UMGEBUNGSVARIABLEN",
+		rtypes.SectionFiles:       "DATEIEN",
+		rtypes.SectionSeeAlso:     "SIEHE AUCH",
+		"Arguments":               "Argumente",
+		"Global Options":          "Globale Optionen",
+		"Aliases":                 "Aliasse",
+		"Code":                    "Code",
+		"Meaning":                 "Bedeutung",
+	},
+	"fr": {
+		rtypes.SectionName:        "NOM",
+		rtypes.SectionSynopsis:    "SYNOPSIS",
+		rtypes.SectionDescription: "DESCRIPTION",
+		rtypes.SectionOptions:     "OPTIONS",
+		rtypes.SectionCommands:    "COMMANDES",
+		rtypes.SectionExamples:    "EXEMPLES",
+		rtypes.SectionExitStatus:  "CODE DE RETOUR",
+		rtypes.SectionEnvironment: "ENVIRONNEMENT",
+		rtypes.SectionFiles:       "FICHIERS",
+		rtypes.SectionSeeAlso:     "VOIR AUSSI",
+		"Arguments":               "Arguments",
+		"Global Options":          "Options globales",
+		"Aliases":                 "Alias",
+		"Code":                    "Code",
+		"Meaning":                 "Signification",
+	},
+	"es": {
+		rtypes.SectionName:        "NOMBRE",
+		rtypes.SectionSynopsis:    "SINOPSIS",
+		rtypes.SectionDescription: "DESCRIPCIÓN",
+		rtypes.SectionOptions:     "OPCIONES",
+		rtypes.SectionCommands:    "ÓRDENES",
+		rtypes.SectionExamples:    "EJEMPLOS",
+		rtypes.SectionExitStatus:  "ESTADO DE SALIDA",
+		rtypes.SectionEnvironment: "ENTORNO",
+		rtypes.SectionFiles:       "ARCHIVOS",
+		rtypes.SectionSeeAlso:     "VÉASE TAMBIÉN",
+		"Arguments":               "Argumentos",
+		"Global Options":          "Opciones globales",
+		"Aliases":                 "Alias",
+		"Code":                    "Código",
+		"Meaning":                 "Significado",
+	},
+	"pt": {
+		rtypes.SectionName:        "NOME",
+		rtypes.SectionSynopsis:    "SINOPSE",
+		rtypes.SectionDescription: "DESCRIÇÃO",
+		rtypes.SectionOptions:     "OPÇÕES",
+		rtypes.SectionCommands:    "COMANDOS",
+		rtypes.SectionExamples:    "EXEMPLOS",
+		rtypes.SectionExitStatus:  "STATUS DE SAÍDA",
+		rtypes.SectionEnvironment: "AMBIENTE",
+		rtypes.SectionFiles:       "ARQUIVOS",
+		rtypes.SectionSeeAlso:     "VEJA TAMBÉM",
+		"Arguments":               "Argumentos",
+		"Global Options":          "Opções globais",
+		"Aliases":                 "Apelidos",
+		"Code":                    "Código",
+		"Meaning":                 "Significado",
+	},
+}
+
+// manLabels localizes the fixed strings of a man page, such as the section
+// headings.
+type manLabels struct {
+	tr   help.Translator
+	lang string
+}
+
+// get returns the translation of a fixed string: the translator is tried
+// first, using the English text as key, then the built-in translations of
+// the language, falling back to English.
+func (l manLabels) get(s string) string {
+	if l.tr != nil {
+		if translated := l.tr(s); translated != "" && translated != s {
+			return translated
+		}
+	}
+	if translated, ok := manHeadings[baseLanguage(l.lang)][s]; ok {
+		return translated
+	}
+	return s
+}
+
+// baseLanguage returns the language of a locale, e.g. "pt" for
+// "pt_BR.UTF-8".
+func baseLanguage(locale string) string {
+	lang, _, _ := strings.Cut(locale, "@")
+	lang, _, _ = strings.Cut(lang, ".")
+	lang, _, _ = strings.Cut(lang, "_")
+	lang, _, _ = strings.Cut(lang, "-")
+	return strings.ToLower(lang)
+}
+
+// SetLanguage sets the language of the generated man pages, used to
+// localize the section headings when the translator does not provide them.
+//
+// Example:
+//
+//	myApp.CLI.SetLanguage(myApp.LC.Language().String())
+func (c *Command) SetLanguage(lang string) {
+	c.language = lang
+}
+
+// LocalizedManPage generates the man page of the command like ManPage,
+// using the given translator for the descriptions and the section headings
+// and lang for the built-in headings translations. Headings are looked up
+// in the translator by their English text, e.g. "SEE ALSO", so catalogs
+// can override the built-in ones.
+//
+// Example:
+//
+//	man, err := myApp.CLI.LocalizedManPage("it", italian.Get)
+func (c *Command) LocalizedManPage(lang string, tr help.Translator) (string, error) {
+	node, err := cleanNode(c.root)
+	if err != nil {
+		return "", err
+	}
+
+	for name, meta := range c.app.RootNode.Flags {
+		if _, ok := node.Flags[name]; !ok {
+			node.Flags[name] = meta
+		}
+	}
+	for _, name := range []string{"man", "completion", "help"} {
+		child, ok := c.app.RootNode.Children[name]
+		if _, exists := node.Children[name]; ok && !exists {
+			node.Children[name] = child
+		}
+	}
+
+	translate := func(s string) string { return translateWith(tr, s) }
+	pages := buildDocPages(node, c.programName(), nil, nil, translate, docDate())
+	if pages[0].Description == "" {
+		pages[0].Description = translateWith(tr, c.Short)
+	}
+	pages[0].Long = translateWith(tr, c.Long)
+	return renderManPage(pages, 1, manLabels{tr: tr, lang: lang}), nil
+}
+
+// GenerateManPages writes the man page of the command into
+// dir/man1/<name>.1, using the command translator, and one page per locale
+// into dir/<lang>/man1/<name>.1, the layout expected by man. It returns the
+// paths of the generated files.
+//
+// Example:
+//
+//	files, err := myApp.CLI.GenerateManPages("man", map[string]help.Translator{
+//		"it": italian.Get,
+//		"de": german.Get,
+//	})
+func (c *Command) GenerateManPages(dir string, locales map[string]help.Translator) ([]string, error) {
+	write := func(subdir, lang string, tr help.Translator) (string, error) {
+		man, err := c.LocalizedManPage(lang, tr)
+		if err != nil {
+			return "", err
+		}
+
+		path := filepath.Join(dir, subdir, "man1", c.programName()+".1")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return "", err
+		}
+		return path, os.WriteFile(path, []byte(man), 0644)
+	}
+
+	path, err := write("", c.language, c.translator)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+
+	for _, lang := range sortedKeys(locales) {
+		path, err := write(lang, lang, locales[lang])
+		if err != nil {
+			return files, fmt.Errorf("cannot generate the %s man page: %w", lang, err)
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// manPageRe matches the path of a man page relative to a man directory,
+// e.g. "man1/myapp.1" or "it/man1/myapp.1".
+var manPageRe = regexp.MustCompile(`^(?:[^/]+/)?man([1-9])/[^/]+\.([1-9])[a-z]*$`)
+
+// InstallManPages copies the man pages found in src, laid out as written by
+// GenerateManPages, into dest, usually "$DESTDIR/usr/share/man", keeping
+// the language and section directories. Pages are gzip compressed if
+// compress is true. It returns the paths of the installed files.
+//
+// Example:
+//
+//	// in a build script, after myApp.CLI.GenerateManPages("man", locales)
+//	files, err := cli.InstallManPages("man", filepath.Join(os.Getenv("DESTDIR"), "/usr/share/man"), true)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Installed %d man pages\n", len(files))
+func InstallManPages(src, dest string, compress bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		match := manPageRe.FindStringSubmatch(filepath.ToSlash(rel))
+		if match == nil || match[1] != match[2] {
+			return nil
+		}
+
+		target := filepath.Join(dest, rel)
+		if compress {
+			target += ".gz"
+		}
+		if err := installManPage(path, target, compress); err != nil {
+			return fmt.Errorf("cannot install %s: %w", rel, err)
+		}
+		files = append(files, target)
+		return nil
+	})
+	return files, err
+}
+
+// installManPage copies a single page, optionally compressing it.
+func installManPage(src, dest string, compress bool) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	var w io.WriteCloser = out
+	if compress {
+		w = gzip.NewWriter(out)
+	}
+	_, err = io.Copy(w, in)
+	if compress {
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
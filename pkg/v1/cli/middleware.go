@@ -0,0 +1,288 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Middlewares wrapping the execution of commands.
+*/
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	builder "github.com/mirkobrombin/go-cli-builder/v2/pkg/cli"
+	"github.com/mirkobrombin/go-cli-builder/v2/pkg/log"
+	"github.com/mirkobrombin/go-cli-builder/v2/pkg/parser"
+	"github.com/vanilla-os/sdk/pkg/v1/system"
+)
+
+var (
+	// ErrRootRequired is returned by the RequireRoot middleware when the
+	// command is not run as root.
+	ErrRootRequired = errors.New("this command must be run as root")
+
+	// ErrContainerNotAllowed is returned by the RequireNotContainer
+	// middleware when the command is run inside a container.
+	ErrContainerNotAllowed = errors.New("this command cannot be run inside a container")
+)
+
+// Invocation describes the command being executed. It is passed to the
+// middlewares once flags and arguments are bound.
+type Invocation struct {
+	// CLI is the command line interface the command belongs to
+	CLI *Command
+
+	// Node is the parsed node of the command
+	Node *parser.CommandNode
+
+	// Path is the path of the command without the program name, e.g.
+	// "config set", empty for the root command
+	Path string
+
+	// Target is a pointer to the command struct
+	Target any
+
+	// Flags holds the flags of the command, including the inherited ones
+	Flags map[string]*parser.FlagMetadata
+
+	// Context is set as the Ctx of the command, middlewares can wrap it,
+	// e.g. to add a timeout or values
+	Context context.Context
+}
+
+// Flag returns the value of a flag of the command.
+//
+// Example:
+//
+//	if verbose, _ := inv.Flag("verbose"); verbose == true {
+//		myApp.Log.Term.Info().Msg("Verbose mode enabled")
+//	}
+func (i *Invocation) Flag(name string) (any, bool) {
+	meta, ok := i.Flags[name]
+	if !ok || !meta.Field.IsValid() {
+		return nil, false
+	}
+	return meta.Field.Interface(), true
+}
+
+// Middleware wraps the execution of a command, next runs the rest of the
+// chain and eventually the command itself. Returning without calling next
+// prevents the command from running.
+//
+// Example:
+//
+//	func Timing(inv *cli.Invocation, next func() error) error {
+//		start := time.Now()
+//		err := next()
+//		myApp.Log.File.Info().Msgf("%s took %s", inv.Path, time.Since(start))
+//		return err
+//	}
+type Middleware func(inv *Invocation, next func() error) error
+
+// AddMiddleware appends middlewares to the chain wrapping every command, they run in
+// the order they are added. Middlewares run after the Before methods of the
+// parent commands and wrap the Before, Run and After methods of the command
+// being executed. Commands without a Run method only print their help and
+// skip the chain.
+//
+// Example:
+//
+//	myApp.CLI.AddMiddleware(
+//		cli.RequireNotContainer(),
+//		cli.ForCommands(cli.RequireRoot(), "install", "remove"),
+//		Timing,
+//	)
+func (c *Command) AddMiddleware(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// BeforeHook returns a middleware calling fn before the command, which
+// does not run if fn returns an error.
+//
+// Example:
+//
+//	myApp.CLI.AddMiddleware(cli.BeforeHook(func(inv *cli.Invocation) error {
+//		return config.Load()
+//	}))
+func BeforeHook(fn func(inv *Invocation) error) Middleware {
+	return func(inv *Invocation, next func() error) error {
+		if err := fn(inv); err != nil {
+			return err
+		}
+		return next()
+	}
+}
+
+// AfterHook returns a middleware calling fn after the command with the
+// error it returned, fn's result is the result of the command.
+//
+// Example:
+//
+//	myApp.CLI.AddMiddleware(cli.AfterHook(func(inv *cli.Invocation, err error) error {
+//		telemetry.Record(inv.Path, err)
+//		return err
+//	}))
+func AfterHook(fn func(inv *Invocation, err error) error) Middleware {
+	return func(inv *Invocation, next func() error) error {
+		return fn(inv, next())
+	}
+}
+
+// ForCommands restricts a middleware to the commands at the given paths,
+// e.g. "install" or "config set", and their subcommands.
+//
+// Example:
+//
+//	myApp.CLI.AddMiddleware(cli.ForCommands(cli.RequireRoot(), "install", "remove"))
+func ForCommands(middleware Middleware, paths ...string) Middleware {
+	return func(inv *Invocation, next func() error) error {
+		if slices.ContainsFunc(paths, func(path string) bool {
+			return inv.Path == path || strings.HasPrefix(inv.Path, path+" ")
+		}) {
+			return middleware(inv, next)
+		}
+		return next()
+	}
+}
+
+// RequireRoot returns a middleware failing with ErrRootRequired if the
+// command is not run as root.
+//
+// Example:
+//
+//	myApp.CLI.AddMiddleware(cli.RequireRoot())
+func RequireRoot() Middleware {
+	return func(inv *Invocation, next func() error) error {
+		if os.Geteuid() != 0 {
+			return ErrRootRequired
+		}
+		return next()
+	}
+}
+
+// RequireNotContainer returns a middleware failing with
+// ErrContainerNotAllowed if the command is run inside a container.
+//
+// Example:
+//
+//	myApp.CLI.AddMiddleware(cli.RequireNotContainer())
+func RequireNotContainer() Middleware {
+	return func(inv *Invocation, next func() error) error {
+		if system.RunningInContainer() {
+			return ErrContainerNotAllowed
+		}
+		return next()
+	}
+}
+
+// wrapTarget resolves the command the arguments point to, like the builder
+// does, and replaces its value with a runner executing the middleware
+// chain. The returned function restores the original value.
+func (c *Command) wrapTarget(args []string) func() {
+	node := c.app.RootNode
+	var path []string
+	flags := make(map[string]*parser.FlagMetadata)
+	for name, meta := range node.Flags {
+		flags[name] = meta
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		child, ok := node.Children[arg]
+		if !ok {
+			break
+		}
+		node = child
+		path = append(path, child.Name)
+		for name, meta := range node.Flags {
+			flags[name] = meta
+		}
+	}
+
+	target := node.Value
+	if target.Kind() != reflect.Ptr && target.CanAddr() {
+		target = target.Addr()
+	}
+	if target.Kind() != reflect.Ptr {
+		return func() {}
+	}
+	if _, ok := target.Interface().(builder.Runner); !ok {
+		return func() {}
+	}
+
+	original := node.Value
+	node.Value = reflect.ValueOf(&middlewareRunner{
+		middlewares: c.middlewares,
+		target:      target,
+		inv: &Invocation{
+			CLI:     c,
+			Node:    node,
+			Path:    strings.Join(path, " "),
+			Target:  target.Interface(),
+			Flags:   flags,
+			Context: context.Background(),
+		},
+	})
+	return func() {
+		node.Value = original
+	}
+}
+
+// middlewareRunner takes the place of the command being executed, running
+// the middleware chain around it.
+type middlewareRunner struct {
+	// Base is filled by the builder in place of the one of the command,
+	// see parseMarker
+	Base
+
+	middlewares []Middleware
+	target      reflect.Value
+	inv         *Invocation
+}
+
+// Run runs the middleware chain and the command.
+func (r *middlewareRunner) Run() error {
+	next := r.runTarget
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		middleware, inner := r.middlewares[i], next
+		next = func() error {
+			return middleware(r.inv, inner)
+		}
+	}
+	return next()
+}
+
+// runTarget runs the command as the builder would, injecting the context
+// of the invocation.
+func (r *middlewareRunner) runTarget() error {
+	elem := r.target.Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		if elem.Type().Field(i).Type == reflect.TypeFor[Base]() && elem.Field(i).CanSet() {
+			elem.Field(i).Set(reflect.ValueOf(Base{
+				Logger: log.New(),
+				Ctx:    r.inv.Context,
+			}))
+		}
+	}
+
+	cmd := r.target.Interface()
+	if before, ok := cmd.(builder.BeforeRunner); ok {
+		if err := before.Before(); err != nil {
+			return err
+		}
+	}
+	if err := cmd.(builder.Runner).Run(); err != nil {
+		return err
+	}
+	if after, ok := cmd.(builder.AfterRunner); ok {
+		return after.After()
+	}
+	return nil
+}
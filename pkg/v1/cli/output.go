@@ -0,0 +1,470 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Structured output rendering (table, JSON, YAML, plain text).
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mattn/go-isatty"
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseOutputFormat parses the value of the --output flag. An empty value
+// results in types.OutputAuto.
+//
+// Example:
+//
+//	format, err := cli.ParseOutputFormat("json")
+//	if err != nil {
+//		fmt.Println(err)
+//		return
+//	}
+func ParseOutputFormat(value string) (types.OutputFormat, error) {
+	format := types.OutputFormat(strings.ToLower(strings.TrimSpace(value)))
+	if format == types.OutputAuto {
+		return format, nil
+	}
+
+	for _, known := range types.OutputFormats {
+		if format == known {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported output format %q, expected one of: table, json, yaml, plain", value)
+}
+
+// SetOutputFormat forces the output format, overriding the --output flag.
+func (c *Command) SetOutputFormat(format types.OutputFormat) {
+	c.output = string(format)
+}
+
+// OutputFormat returns the output format requested through the --output
+// flag. If no format was requested, OutputTable is used on a terminal and
+// OutputPlain otherwise, so that pipes never receive styled output.
+//
+// Example:
+//
+//	format, err := myApp.CLI.OutputFormat()
+//	if err != nil {
+//		return err
+//	}
+//	if format == types.OutputJSON {
+//		// skip decorations meant for humans
+//	}
+func (c *Command) OutputFormat() (types.OutputFormat, error) {
+	format, err := ParseOutputFormat(c.output)
+	if err != nil {
+		return "", err
+	}
+
+	if format == types.OutputAuto {
+		if isTerminal(c.writer()) {
+			return types.OutputTable, nil
+		}
+		return types.OutputPlain, nil
+	}
+
+	return format, nil
+}
+
+// Output renders any struct, slice or map using the format requested via
+// the --output flag. Table columns are taken from the exported struct
+// fields, using the `json` tag for their name; a field can be renamed with
+// the `output:"Header"` tag or hidden with `output:"-"`.
+//
+// Example:
+//
+//	users, err := system.GetAllUsers(false)
+//	if err != nil {
+//		return err
+//	}
+//	return myApp.CLI.Output(users)
+func (c *Command) Output(data any) error {
+	format, err := c.OutputFormat()
+	if err != nil {
+		return err
+	}
+	return RenderOutput(c.writer(), data, format)
+}
+
+// RenderOutput renders data to w using the given format. Unlike Output, it
+// does not look at the --output flag nor at the terminal, OutputAuto is
+// treated as OutputPlain.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	err := cli.RenderOutput(&buf, heroes, types.OutputJSON)
+func RenderOutput(w io.Writer, data any, format types.OutputFormat) error {
+	switch format {
+	case types.OutputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	case types.OutputYAML:
+		node, err := toYAMLNode(data)
+		if err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(node); err != nil {
+			return err
+		}
+		return enc.Close()
+	case types.OutputTable:
+		t := tabulate(data)
+		_, err := fmt.Fprintln(w, newTable(t.headers, t.rows))
+		return err
+	case types.OutputPlain, types.OutputAuto:
+		return renderPlain(w, tabulate(data))
+	}
+
+	return fmt.Errorf("unsupported output format %q", format)
+}
+
+// renderPlain writes tabular data as tab aligned text without any styling.
+// Key/value data is printed without the header row.
+func renderPlain(w io.Writer, t tabularData) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if !t.keyValue && len(t.headers) > 1 {
+		upper := make([]string, len(t.headers))
+		for i, h := range t.headers {
+			upper[i] = strings.ToUpper(h)
+		}
+		fmt.Fprintln(tw, strings.Join(upper, "\t"))
+	}
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// tabularData is the intermediate representation used by the table and
+// plain renderers.
+type tabularData struct {
+	headers  []string
+	rows     [][]string
+	keyValue bool
+}
+
+// column describes a struct field rendered as a table column.
+type column struct {
+	header string
+	index  []int
+}
+
+// tabulate converts a struct, a slice or a map into rows and columns.
+func tabulate(data any) tabularData {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return tabularData{headers: []string{"Value"}}
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		elemType := derefType(v.Type().Elem())
+		if elemType.Kind() == reflect.Struct && !isStringer(v.Type().Elem()) {
+			cols := columnsOf(elemType)
+			t := tabularData{headers: headersOf(cols)}
+			for i := 0; i < v.Len(); i++ {
+				t.rows = append(t.rows, rowOf(v.Index(i), cols))
+			}
+			return t
+		}
+
+		t := tabularData{headers: []string{"Value"}}
+		for i := 0; i < v.Len(); i++ {
+			t.rows = append(t.rows, []string{formatCell(v.Index(i))})
+		}
+		return t
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+
+		elemType := derefType(v.Type().Elem())
+		if elemType.Kind() == reflect.Struct && !isStringer(v.Type().Elem()) {
+			cols := columnsOf(elemType)
+			t := tabularData{headers: append([]string{"Key"}, headersOf(cols)...)}
+			for _, k := range keys {
+				row := append([]string{formatCell(k)}, rowOf(v.MapIndex(k), cols)...)
+				t.rows = append(t.rows, row)
+			}
+			return t
+		}
+
+		t := tabularData{headers: []string{"Key", "Value"}, keyValue: true}
+		for _, k := range keys {
+			t.rows = append(t.rows, []string{formatCell(k), formatCell(v.MapIndex(k))})
+		}
+		return t
+	case reflect.Struct:
+		if isStringer(v.Type()) {
+			break
+		}
+		t := tabularData{headers: []string{"Field", "Value"}, keyValue: true}
+		for _, col := range columnsOf(v.Type()) {
+			t.rows = append(t.rows, []string{col.header, formatCell(fieldByIndex(v, col.index))})
+		}
+		return t
+	}
+
+	return tabularData{headers: []string{"Value"}, rows: [][]string{{formatCell(v)}}}
+}
+
+// columnsOf returns the columns of a struct type, flattening embedded
+// structs the same way encoding/json does.
+func columnsOf(t reflect.Type) []column {
+	var cols []column
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		outputTag := field.Tag.Get("output")
+		if outputTag == "-" {
+			continue
+		}
+
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonName == "-" && outputTag == "" {
+			continue
+		}
+
+		ft := derefType(field.Type)
+		if field.Anonymous && jsonName == "" && ft.Kind() == reflect.Struct {
+			for _, sub := range columnsOf(ft) {
+				sub.index = append([]int{i}, sub.index...)
+				cols = append(cols, sub)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		header := outputTag
+		switch {
+		case header != "":
+		case jsonName != "" && jsonName != "-":
+			header = humanizeHeader(jsonName)
+		default:
+			header = field.Name
+		}
+
+		cols = append(cols, column{header: header, index: []int{i}})
+	}
+	return cols
+}
+
+// headersOf returns the headers of the given columns.
+func headersOf(cols []column) []string {
+	headers := make([]string, len(cols))
+	for i, col := range cols {
+		headers[i] = col.header
+	}
+	return headers
+}
+
+// rowOf formats the columns of a struct value.
+func rowOf(v reflect.Value, cols []column) []string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return make([]string, len(cols))
+		}
+		v = v.Elem()
+	}
+
+	row := make([]string, len(cols))
+	for i, col := range cols {
+		row[i] = formatCell(fieldByIndex(v, col.index))
+	}
+	return row
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but returns an invalid
+// value instead of panicking on nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, idx := range index {
+		if i > 0 {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return reflect.Value{}
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(idx)
+	}
+	return v
+}
+
+// formatCell formats a single value for table and plain output.
+func formatCell(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+
+	if v.CanInterface() {
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			if v.Kind() != reflect.Ptr || !v.IsNil() {
+				return s.String()
+			}
+		}
+	}
+	if v.CanAddr() && v.Addr().CanInterface() {
+		if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return formatCell(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%x", v.Interface())
+		}
+		items := make([]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items[i] = formatCell(v.Index(i))
+		}
+		return strings.Join(items, ", ")
+	case reflect.Map, reflect.Struct:
+		if !v.CanInterface() {
+			return ""
+		}
+		raw, err := json.Marshal(v.Interface())
+		if err != nil {
+			return fmt.Sprint(v.Interface())
+		}
+		return string(raw)
+	}
+
+	if !v.CanInterface() {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// humanizeHeader turns a json field name like "home_directory" into
+// "Home Directory".
+func humanizeHeader(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isStringer(t reflect.Type) bool {
+	stringer := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	return t.Implements(stringer) || reflect.PointerTo(t).Implements(stringer)
+}
+
+// toYAMLNode converts data into a YAML node going through its JSON
+// representation, so that `json` tags, omitempty and custom marshalers are
+// honored and the field order is preserved.
+func toYAMLNode(data any) (*yaml.Node, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return decodeYAMLNode(dec)
+}
+
+func decodeYAMLNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if t == '{' {
+			node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		for dec.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{
+					Kind:  yaml.ScalarNode,
+					Tag:   "!!str",
+					Value: fmt.Sprint(key),
+				})
+			}
+			child, err := decodeYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		// consume the closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(t.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(t)}, nil
+	}
+
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+}
+
+// isTerminal reports whether w is a file attached to a terminal.
+func isTerminal(w any) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
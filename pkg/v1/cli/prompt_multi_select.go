@@ -0,0 +1,200 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Multiple selection prompt implementation using Bubble Tea.
+*/
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// multiSelectHeight is the maximum number of options shown at once.
+const multiSelectHeight = 10
+
+type multiSelectModel struct {
+	prompt   string
+	options  []string
+	cursor   int
+	checked  map[int]bool
+	minCount int
+	maxCount int
+	errMsg   string
+	err      error
+
+	// allowBack makes esc go back to the previous form step
+	allowBack bool
+	back      bool
+}
+
+func initialMultiSelectModel(prompt string, options []string, minCount, maxCount int) multiSelectModel {
+	return multiSelectModel{
+		prompt:   prompt,
+		options:  options,
+		checked:  make(map[int]bool),
+		minCount: minCount,
+		maxCount: maxCount,
+	}
+}
+
+func (m multiSelectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m multiSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	m.errMsg = ""
+	switch keyMsg.String() {
+	case "ctrl+c", "esc":
+		if keyMsg.String() == "esc" && m.allowBack {
+			m.back = true
+			return m, tea.Quit
+		}
+		m.err = fmt.Errorf("interrupted")
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.options)-1 {
+			m.cursor++
+		}
+	case " ", "x":
+		m.toggle(m.cursor)
+	case "a":
+		if len(m.checked) > 0 {
+			m.checked = make(map[int]bool)
+		} else {
+			for i := range m.options {
+				m.toggle(i)
+			}
+		}
+	case "enter":
+		if err := checkSelectionCount(len(m.checked), m.minCount, m.maxCount); err != nil {
+			m.errMsg = err.Error()
+			return m, nil
+		}
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// toggle checks or unchecks an option, refusing to go above the maximum.
+func (m *multiSelectModel) toggle(idx int) {
+	if m.checked[idx] {
+		delete(m.checked, idx)
+		return
+	}
+	if m.maxCount > 0 && len(m.checked) >= m.maxCount {
+		m.errMsg = fmt.Sprintf("select at most %d options", m.maxCount)
+		return
+	}
+	m.checked[idx] = true
+}
+
+func (m multiSelectModel) View() string {
+	var s strings.Builder
+	s.WriteString("\n" + lipgloss.NewStyle().Bold(true).Render(m.prompt) + "\n\n")
+
+	start := 0
+	if m.cursor >= multiSelectHeight {
+		start = m.cursor - multiSelectHeight + 1
+	}
+	end := min(start+multiSelectHeight, len(m.options))
+
+	for i := start; i < end; i++ {
+		box := "[ ]"
+		if m.checked[i] {
+			box = "[x]"
+		}
+		line := fmt.Sprintf("%s %s", box, m.options[i])
+		if i == m.cursor {
+			s.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+		} else {
+			s.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+
+	s.WriteString(helpStyle.Render("space: toggle • a: toggle all • enter: confirm") + "\n")
+	if m.errMsg != "" {
+		s.WriteString(errorStyle.Render("✗ "+m.errMsg) + "\n")
+	}
+	return s.String()
+}
+
+// selected returns the checked options in their original order.
+func (m multiSelectModel) selected() []string {
+	selected := []string{}
+	for i, opt := range m.options {
+		if m.checked[i] {
+			selected = append(selected, opt)
+		}
+	}
+	return selected
+}
+
+// checkSelectionCount verifies the number of selected options is within
+// the given bounds, a maximum of 0 means no upper bound.
+func checkSelectionCount(count, minCount, maxCount int) error {
+	if count < minCount {
+		return fmt.Errorf("select at least %d options", minCount)
+	}
+	if maxCount > 0 && count > maxCount {
+		return fmt.Errorf("select at most %d options", maxCount)
+	}
+	return nil
+}
+
+// SelectMultipleOptions prompts the user to check any number of options
+// from a list, between minCount and maxCount (0 means no upper bound). The
+// selected options are returned in the order they were provided.
+//
+// In non-interactive mode an empty selection is returned if minCount is 0,
+// otherwise an error wrapping ErrNonInteractive is returned.
+//
+// Example:
+//
+//	gadgets, err := myApp.CLI.SelectMultipleOptions(
+//		"Which gadgets should Batman bring?",
+//		[]string{"Batarang", "Grappling hook", "Smoke pellets", "Batclaw"},
+//		1, 3,
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Packing %s\n", strings.Join(gadgets, ", "))
+func (c *Command) SelectMultipleOptions(prompt string, options []string, minCount, maxCount int) ([]string, error) {
+	if !c.Interactive() {
+		if minCount > 0 {
+			return nil, nonInteractiveError(prompt)
+		}
+		return []string{}, nil
+	}
+
+	p := tea.NewProgram(initialMultiSelectModel(prompt, options, minCount, maxCount))
+	m, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	if m, ok := m.(multiSelectModel); ok {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return m.selected(), nil
+	}
+
+	return nil, fmt.Errorf("could not retrieve selection")
+}
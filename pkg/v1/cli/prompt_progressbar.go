@@ -10,6 +10,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -68,6 +69,11 @@ type ProgressBarModel struct {
 	program *tea.Program
 	total   int
 	current int
+
+	// plain is used instead of program in non-interactive mode
+	plain    io.Writer
+	message  string
+	lastStep int
 }
 
 // StartProgressBar starts a progress bar with a message and a total.
@@ -81,7 +87,20 @@ type ProgressBarModel struct {
 //		progressBar.Increment(1)
 //		time.Sleep(50 * time.Millisecond)
 //	}
+//
+// In non-interactive mode no bar is drawn, the progress is printed as plain
+// lines every 10% instead.
 func (c Command) StartProgressBar(message string, total int) *ProgressBarModel {
+	if !c.Interactive() {
+		w := c.errWriter()
+		fmt.Fprintln(w, message)
+		return &ProgressBarModel{
+			total:   total,
+			plain:   w,
+			message: message,
+		}
+	}
+
 	p := progress.New(
 		progress.WithGradient("#277eff", "#e0388d"),
 		progress.WithoutPercentage(),
@@ -107,8 +126,14 @@ func (c Command) StartProgressBar(message string, total int) *ProgressBarModel {
 	}
 }
 
+// Increment advances the progress bar by inc steps.
 func (m *ProgressBarModel) Increment(inc int) {
 	m.current += inc
+	if m.plain != nil {
+		m.printStep()
+		return
+	}
+
 	if m.current >= m.total {
 		m.current = m.total
 		m.Stop()
@@ -122,11 +147,38 @@ func (m *ProgressBarModel) Increment(inc int) {
 
 // UpdateMessage updates the title logic.
 func (m *ProgressBarModel) UpdateMessage(msg string) {
+	if m.plain != nil {
+		m.message = msg
+		fmt.Fprintln(m.plain, msg)
+		return
+	}
 	m.program.Send(titleMsg(msg))
 }
 
+// Stop stops the progress bar.
 func (m *ProgressBarModel) Stop() {
+	if m.plain != nil {
+		return
+	}
 	m.program.Send(stopMsg{})
 	// Allow cleanup
 	time.Sleep(100 * time.Millisecond)
 }
+
+// printStep prints the progress as a plain line each time a new 10% step
+// is reached.
+func (m *ProgressBarModel) printStep() {
+	if m.total <= 0 {
+		return
+	}
+	if m.current > m.total {
+		m.current = m.total
+	}
+
+	step := m.current * 10 / m.total
+	if step <= m.lastStep {
+		return
+	}
+	m.lastStep = step
+	fmt.Fprintf(m.plain, "%s %d%%\n", m.message, step*10)
+}
@@ -28,7 +28,7 @@ var (
 
 type item string
 
-func (i item) FilterValue() string { return "" }
+func (i item) FilterValue() string { return string(i) }
 
 type itemDelegate struct{}
 
@@ -57,6 +57,10 @@ type listModel struct {
 	list     list.Model
 	selected string
 	err      error
+
+	// allowBack makes esc go back to the previous form step
+	allowBack bool
+	back      bool
 }
 
 func initialListModel(prompt string, options []string) listModel {
@@ -70,7 +74,7 @@ func initialListModel(prompt string, options []string) listModel {
 	l := list.New(items, itemDelegate{}, defaultWidth, 14)
 	l.Title = prompt
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
+	l.SetFilteringEnabled(true)
 	l.Styles.Title = titleStyle
 	l.Styles.PaginationStyle = paginationStyle
 	l.Styles.HelpStyle = helpStyle
@@ -85,11 +89,25 @@ func (m listModel) Init() tea.Cmd {
 func (m listModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// while the user is typing a filter, keys belong to the filter
+		if m.list.FilterState() == list.Filtering {
+			if msg.String() == "ctrl+c" {
+				m.err = fmt.Errorf("interrupted")
+				return m, tea.Quit
+			}
+			break
+		}
+
 		key := msg.String()
 		switch key {
 		case "ctrl+c":
 			m.err = fmt.Errorf("interrupted")
 			return m, tea.Quit
+		case "esc":
+			if m.allowBack && m.list.FilterState() == list.Unfiltered {
+				m.back = true
+				return m, tea.Quit
+			}
 		case "enter":
 			if i, ok := m.list.SelectedItem().(item); ok {
 				m.selected = string(i)
@@ -97,7 +115,7 @@ func (m listModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
 			idx := int(key[0] - '1')
-			items := m.list.Items()
+			items := m.list.VisibleItems()
 			if idx >= 0 && idx < len(items) {
 				if i, ok := items[idx].(item); ok {
 					m.selected = string(i)
@@ -120,6 +138,10 @@ func (m listModel) View() string {
 }
 
 // SelectOption prompts the user to select an option from a list of options.
+// Pressing "/" starts fuzzy filtering of the options.
+//
+// Since there is no default option, an error wrapping ErrNonInteractive is
+// returned in non-interactive mode.
 //
 // Example:
 //
@@ -136,6 +158,10 @@ func (c *Command) SelectOption(prompt string, options []string) (string, error)
 	if strings.Contains(prompt, "%d") {
 		prompt = fmt.Sprintf(prompt, len(options))
 	}
+	if !c.Interactive() {
+		return "", nonInteractiveError(prompt)
+	}
+
 	p := tea.NewProgram(initialListModel(prompt, options))
 	m, err := p.Run()
 	if err != nil {
@@ -10,6 +10,7 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -20,6 +21,9 @@ import (
 type SpinnerModel struct {
 	program *tea.Program
 	quit    chan struct{}
+
+	// plain is used instead of program in non-interactive mode
+	plain io.Writer
 }
 
 type spinnerComponent struct {
@@ -71,12 +75,21 @@ func (m spinnerComponent) View() string {
 // StartSpinner starts a spinner with a message.
 // The spinner can be stopped by calling the Stop method on the returned model.
 //
+// In non-interactive mode no spinner is drawn, the message and its updates
+// are printed as plain lines instead.
+//
 // Example:
 //
 //	spinner := myApp.CLI.StartSpinner("Loading the batmobile...")
 //	time.Sleep(3 * time.Second)
 //	spinner.Stop()
 func (c Command) StartSpinner(message string) *SpinnerModel {
+	if !c.Interactive() {
+		w := c.errWriter()
+		fmt.Fprintln(w, message)
+		return &SpinnerModel{plain: w}
+	}
+
 	p := tea.NewProgram(initialSpinnerComponent(message))
 	quit := make(chan struct{})
 
@@ -100,6 +113,8 @@ func (c Command) StartSpinner(message string) *SpinnerModel {
 func (m *SpinnerModel) UpdateMessage(message string) {
 	if m.program != nil {
 		m.program.Send(updateMsg(message))
+	} else if m.plain != nil {
+		fmt.Fprintln(m.plain, message)
 	}
 }
 
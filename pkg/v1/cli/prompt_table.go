@@ -27,13 +27,19 @@ import (
 //		},
 //	)
 func (c *Command) Table(headers []string, data [][]string) error {
+	fmt.Fprintln(c.writer(), newTable(headers, data))
+	return nil
+}
+
+// newTable builds the styled lipgloss table shared by Table and Output.
+func newTable(headers []string, data [][]string) *table.Table {
 	headerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true).Padding(0, 1)
 	styledHeaders := make([]string, len(headers))
 	for i, h := range headers {
 		styledHeaders[i] = headerStyle.Render(h)
 	}
 
-	t := table.New().
+	return table.New().
 		Border(lipgloss.NormalBorder()).
 		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("238"))).
 		Headers(styledHeaders...).
@@ -51,7 +57,4 @@ func (c *Command) Table(headers []string, data [][]string) error {
 					Padding(0, 1)
 			}
 		})
-
-	fmt.Println(t)
-	return nil
 }
@@ -16,10 +16,29 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+var errorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+// Validator validates the value entered by the user, returning an error
+// describing the problem if the value is not acceptable.
+type Validator func(value string) error
+
 type textInputModel struct {
-	textInput textinput.Model
-	err       error
-	prompt    string
+	textInput   textinput.Model
+	err         error
+	prompt      string
+	placeholder string
+	validate    Validator
+	errMsg      string
+
+	// confirmPrompt, when set, asks the user to type the value twice
+	confirmPrompt string
+	confirming    bool
+	first         string
+	submitted     string
+
+	// allowBack makes esc go back to the previous form step
+	allowBack bool
+	back      bool
 }
 
 func initialTextInputModel(prompt, placeholder string) textInputModel {
@@ -31,11 +50,20 @@ func initialTextInputModel(prompt, placeholder string) textInputModel {
 	ti.Prompt = "➜ "
 
 	return textInputModel{
-		textInput: ti,
-		prompt:    prompt,
+		textInput:   ti,
+		prompt:      prompt,
+		placeholder: placeholder,
 	}
 }
 
+func initialPasswordModel(prompt, confirmPrompt string) textInputModel {
+	m := initialTextInputModel(prompt, "")
+	m.textInput.EchoMode = textinput.EchoPassword
+	m.textInput.EchoCharacter = '•'
+	m.confirmPrompt = confirmPrompt
+	return m
+}
+
 func (m textInputModel) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -47,11 +75,16 @@ func (m textInputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.Type {
 		case tea.KeyCtrlC, tea.KeyEsc:
+			if msg.Type == tea.KeyEsc && m.allowBack {
+				m.back = true
+				return m, tea.Quit
+			}
 			m.err = fmt.Errorf("interrupted")
 			return m, tea.Quit
 		case tea.KeyEnter:
-			return m, tea.Quit
+			return m.submit()
 		}
+		m.errMsg = ""
 
 	case tea.WindowSizeMsg:
 		m.textInput.Width = msg.Width
@@ -61,17 +94,88 @@ func (m textInputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// submit validates the current value and either quits, moves to the
+// confirmation step or shows the validation error inline.
+func (m textInputModel) submit() (tea.Model, tea.Cmd) {
+	value := m.textInput.Value()
+	if value == "" {
+		value = m.placeholder
+	}
+
+	if m.confirming {
+		if value != m.first {
+			m.errMsg = "the values do not match, please try again"
+			m.confirming = false
+			m.first = ""
+			m.textInput.Reset()
+			return m, nil
+		}
+		m.submitted = value
+		return m, tea.Quit
+	}
+
+	if m.validate != nil {
+		if err := m.validate(value); err != nil {
+			m.errMsg = err.Error()
+			return m, nil
+		}
+	}
+
+	if m.confirmPrompt != "" {
+		m.first = value
+		m.confirming = true
+		m.errMsg = ""
+		m.textInput.Reset()
+		return m, nil
+	}
+
+	m.submitted = value
+	return m, tea.Quit
+}
+
 func (m textInputModel) View() string {
 	var style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-	return fmt.Sprintf(
+
+	prompt := m.prompt
+	if m.confirming {
+		prompt = m.confirmPrompt
+	}
+
+	view := fmt.Sprintf(
 		"%s\n%s\n",
-		style.Bold(true).Render(m.prompt),
+		style.Bold(true).Render(prompt),
 		m.textInput.View(),
 	)
+	if m.errMsg != "" {
+		view += errorStyle.Render("✗ "+m.errMsg) + "\n"
+	}
+	return view
+}
+
+// runTextInput runs a text input model and returns the submitted value.
+func runTextInput(model textInputModel) (string, error) {
+	p := tea.NewProgram(model)
+	m, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+
+	if m, ok := m.(textInputModel); ok {
+		if m.err != nil {
+			return "", m.err
+		}
+		return m.submitted, nil
+	}
+
+	return "", fmt.Errorf("could not retrieve manual input")
 }
 
 // PromptText prompts the user to input a text, it supports customizing the
-// prompt and the placeholder.
+// prompt and the placeholder. If the user does not provide an answer, the
+// placeholder is used.
+//
+// In non-interactive mode the placeholder is returned without prompting, if
+// the placeholder is empty an error wrapping ErrNonInteractive is returned.
 //
 // Example:
 //
@@ -85,21 +189,75 @@ func (m textInputModel) View() string {
 //	}
 //	fmt.Printf("Hello %s!\n", response)
 func (c *Command) PromptText(prompt, placeholder string) (string, error) {
-	p := tea.NewProgram(initialTextInputModel(prompt, placeholder))
-	m, err := p.Run()
-	if err != nil {
-		return "", err
-	}
+	return c.PromptTextWithValidation(prompt, placeholder, nil)
+}
 
-	if m, ok := m.(textInputModel); ok {
-		if m.err != nil {
-			return "", m.err
+// PromptTextWithValidation works like PromptText but checks the answer with
+// the given validator, showing its error inline and asking again until a
+// valid value is provided.
+//
+// In non-interactive mode the placeholder is validated and returned.
+//
+// Example:
+//
+//	hostname, err := myApp.CLI.PromptTextWithValidation(
+//		"Choose a hostname",
+//		"batcomputer",
+//		func(value string) error {
+//			if strings.Contains(value, " ") {
+//				return fmt.Errorf("the hostname cannot contain spaces")
+//			}
+//			return nil
+//		},
+//	)
+func (c *Command) PromptTextWithValidation(prompt, placeholder string, validate Validator) (string, error) {
+	if !c.Interactive() {
+		if placeholder == "" {
+			return "", nonInteractiveError(prompt)
 		}
-		if m.textInput.Value() == "" {
-			return placeholder, nil
+		if validate != nil {
+			if err := validate(placeholder); err != nil {
+				return "", err
+			}
 		}
-		return m.textInput.Value(), nil
+		return placeholder, nil
 	}
 
-	return "", fmt.Errorf("could not retrieve manual input")
+	m := initialTextInputModel(prompt, placeholder)
+	m.validate = validate
+	return runTextInput(m)
+}
+
+// PromptPassword prompts the user to input a password, masking the typed
+// characters. If confirm is true, the user is asked to type the password a
+// second time and the prompt starts over if the two values do not match.
+// Empty passwords are rejected.
+//
+// Since there is no default password, an error wrapping ErrNonInteractive is
+// returned in non-interactive mode.
+//
+// Example:
+//
+//	password, err := myApp.CLI.PromptPassword("Choose a password", true)
+//	if err != nil {
+//		return err
+//	}
+func (c *Command) PromptPassword(prompt string, confirm bool) (string, error) {
+	if !c.Interactive() {
+		return "", nonInteractiveError(prompt)
+	}
+
+	confirmPrompt := ""
+	if confirm {
+		confirmPrompt = "Confirm: " + prompt
+	}
+
+	m := initialPasswordModel(prompt, confirmPrompt)
+	m.validate = func(value string) error {
+		if value == "" {
+			return fmt.Errorf("the password cannot be empty")
+		}
+		return nil
+	}
+	return runTextInput(m)
 }
@@ -23,6 +23,10 @@ type confirmModel struct {
 	choice    bool // true = yes, false = no
 	err       error
 	submitted bool
+
+	// allowBack makes esc go back to the previous form step
+	allowBack bool
+	back      bool
 }
 
 func initialConfirmModel(prompt, yesText, noText string, defaultChoice bool) confirmModel {
@@ -45,6 +49,11 @@ func (m confirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+c":
 			m.err = fmt.Errorf("interrupted")
 			return m, tea.Quit
+		case "esc":
+			if m.allowBack {
+				m.back = true
+				return m, tea.Quit
+			}
 		case "y", "Y":
 			m.choice = true
 		case "n", "N":
@@ -83,6 +92,9 @@ func (m confirmModel) View() string {
 // the prompt and the text for the "yes" and "no" options. If the user does not
 // provide an answer, the default choice is used.
 //
+// In non-interactive mode the default choice is returned without prompting,
+// unless --assume-yes was passed, in which case true is returned.
+//
 // Example:
 //
 //	confirm, err := myApp.CLI.ConfirmAction(
@@ -100,6 +112,13 @@ func (m confirmModel) View() string {
 //		fmt.Println("You don't like Batman...")
 //	}
 func (c *Command) ConfirmAction(prompt, yesText, noText string, defaultChoice bool) (bool, error) {
+	if c.assumeYes {
+		return true, nil
+	}
+	if !c.Interactive() {
+		return defaultChoice, nil
+	}
+
 	// If custom text provided, use it, assuming 'y' maps to yesText and 'n' to noText visual
 	p := tea.NewProgram(initialConfirmModel(prompt, yesText, noText, defaultChoice))
 	m, err := p.Run()
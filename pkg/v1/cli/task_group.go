@@ -0,0 +1,574 @@
+package cli
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Multi-task progress view using Bubble Tea.
+*/
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/phuslu/log"
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+	"github.com/vanilla-os/sdk/pkg/v1/logs"
+)
+
+var (
+	taskDoneStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	taskElapsedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+// TaskGroup renders several concurrent tasks, each one with a spinner or a
+// progress bar, a status message and the elapsed time. Messages printed
+// through the group, or through a logger attached to it, are shown above
+// the live region.
+type TaskGroup struct {
+	title   string
+	program *tea.Program
+	done    chan struct{}
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+
+	// out receives the summary, and the messages once the group is stopped
+	out io.Writer
+
+	// plain is used instead of program in non-interactive mode
+	plain io.Writer
+
+	mu       sync.Mutex
+	tasks    []*Task
+	started  time.Time
+	partial  []byte
+	stopped  bool
+	restores []func()
+}
+
+// Task is a single task of a TaskGroup. All its methods are safe to be
+// called from multiple goroutines.
+type Task struct {
+	group    *TaskGroup
+	name     string
+	total    int
+	current  int
+	status   string
+	state    types.TaskState
+	started  time.Time
+	ended    time.Time
+	err      error
+	lastStep int
+}
+
+type taskGroupComponent struct {
+	group    *TaskGroup
+	spinner  spinner.Model
+	bar      progress.Model
+	quitting bool
+
+	// pending counts the messages waiting to be printed
+	pending int
+}
+
+// taskGroupPrintMsg asks to print a line above the live region, printed
+// is closed once the message is received.
+type taskGroupPrintMsg struct {
+	line    string
+	printed chan struct{}
+}
+
+// taskGroupPrintedMsg reports that a line has been printed.
+type taskGroupPrintedMsg struct{}
+
+// StartTaskGroup starts rendering a group of tasks with the given title.
+// Tasks are added with AddTask or Go and the group must be closed with
+// Wait, which prints a summary of the tasks.
+//
+// In non-interactive mode no live region is drawn, task updates and
+// messages are printed as plain lines instead.
+//
+// Example:
+//
+//	group := myApp.CLI.StartTaskGroup("Preparing the batmobile")
+//	group.AttachLogger(&myApp.Logger)
+//	for _, part := range []string{"engine", "wheels", "armor"} {
+//		group.Go("Installing "+part, 100, func(ctx context.Context, t *cli.Task) error {
+//			for i := 0; i < 100; i++ {
+//				if ctx.Err() != nil {
+//					return ctx.Err()
+//				}
+//				t.Increment(1)
+//				time.Sleep(20 * time.Millisecond)
+//			}
+//			myApp.Logger.Term.Info().Msgf("%s installed", part)
+//			return nil
+//		})
+//	}
+//	if err := group.Wait(); err != nil {
+//		return err
+//	}
+func (c *Command) StartTaskGroup(title string) *TaskGroup {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := &TaskGroup{
+		title:   title,
+		ctx:     ctx,
+		cancel:  cancel,
+		started: time.Now(),
+		done:    make(chan struct{}),
+	}
+
+	if !c.Interactive() {
+		g.plain = c.errWriter()
+		g.out = g.plain
+		if title != "" {
+			fmt.Fprintln(g.plain, title)
+		}
+		close(g.done)
+		return g
+	}
+
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+
+	g.out = c.writer()
+	g.program = tea.NewProgram(taskGroupComponent{
+		group:   g,
+		spinner: s,
+		bar: progress.New(
+			progress.WithGradient("#277eff", "#e0388d"),
+			progress.WithWidth(30),
+		),
+	}, tea.WithOutput(g.out))
+
+	go func() {
+		if _, err := g.program.Run(); err != nil {
+			fmt.Fprintln(c.errWriter(), "Error running task group:", err)
+		}
+
+		// from now on the messages are printed to out, the program would
+		// not receive them
+		g.mu.Lock()
+		g.stopped = true
+		g.mu.Unlock()
+		close(g.done)
+	}()
+
+	return g
+}
+
+// Context returns a context which is canceled when the user interrupts the
+// task group with ctrl+c.
+func (g *TaskGroup) Context() context.Context {
+	return g.ctx
+}
+
+// AddTask adds a running task to the group. A progress bar is shown when
+// total is greater than 0, a spinner otherwise.
+//
+// Example:
+//
+//	task := group.AddTask("Downloading the batsuit", 0)
+//	task.SetStatus("connecting to the batcomputer")
+//	task.Done()
+func (g *TaskGroup) AddTask(name string, total int) *Task {
+	t := &Task{
+		group:   g,
+		name:    name,
+		total:   total,
+		state:   types.TaskRunning,
+		started: time.Now(),
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.tasks = append(g.tasks, t)
+	if g.plain != nil {
+		fmt.Fprintf(g.plain, "%s...\n", name)
+	}
+	return t
+}
+
+// Go adds a task and runs fn in a new goroutine. The task is marked as done
+// or failed depending on the error returned by fn.
+func (g *TaskGroup) Go(name string, total int, fn func(ctx context.Context, t *Task) error) *Task {
+	t := g.AddTask(name, total)
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := fn(g.ctx, t); err != nil {
+			t.Fail(err)
+			return
+		}
+		t.Done()
+	}()
+	return t
+}
+
+// Println prints a message above the live region.
+func (g *TaskGroup) Println(a ...any) {
+	g.printLine(strings.TrimSuffix(fmt.Sprintln(a...), "\n"))
+}
+
+// Write implements io.Writer, each complete line written is printed above
+// the live region.
+func (g *TaskGroup) Write(p []byte) (int, error) {
+	g.mu.Lock()
+	g.partial = append(g.partial, p...)
+	var lines []string
+	for {
+		idx := bytes.IndexByte(g.partial, '\n')
+		if idx < 0 {
+			break
+		}
+		lines = append(lines, string(g.partial[:idx]))
+		g.partial = g.partial[idx+1:]
+	}
+	g.mu.Unlock()
+
+	for _, line := range lines {
+		g.printLine(line)
+	}
+	return len(p), nil
+}
+
+// printLine must not be called with mu held, since sending a message to
+// the program waits for the View method, which locks mu.
+func (g *TaskGroup) printLine(line string) {
+	g.mu.Lock()
+	stopped := g.plain != nil || g.stopped
+	g.mu.Unlock()
+
+	// Program.Println would block forever once the program has exited,
+	// while Send gives up, so the message is acknowledged by Update to
+	// know whether it has been received
+	if !stopped {
+		printed := make(chan struct{})
+		g.program.Send(taskGroupPrintMsg{line: line, printed: printed})
+		select {
+		case <-printed:
+			return
+		case <-g.done:
+			select {
+			case <-printed:
+				return
+			default:
+			}
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	w := g.plain
+	if w == nil {
+		w = g.out
+	}
+	fmt.Fprintln(w, line)
+}
+
+// AttachLogger redirects the terminal logger to the task group, so that log
+// messages are printed above the live region instead of corrupting it. The
+// original output is restored by Wait. Attach loggers before starting the
+// tasks which use them: the first time a logger is attached its writer is
+// replaced with one which can be switched safely while logging.
+func (g *TaskGroup) AttachLogger(l *logs.Logger) {
+	cw, ok := l.Term.Writer.(*log.ConsoleWriter)
+	if !ok {
+		return
+	}
+
+	sw, ok := cw.Writer.(*switchWriter)
+	if !ok {
+		sw = &switchWriter{w: cw.Writer}
+		if sw.w == nil {
+			sw.w = os.Stderr
+		}
+		cw.Writer = sw
+	}
+
+	prev := sw.swap(g)
+	g.mu.Lock()
+	g.restores = append(g.restores, func() { sw.swap(prev) })
+	g.mu.Unlock()
+}
+
+// switchWriter is an io.Writer whose destination can be changed while it
+// is being written to.
+type switchWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write writes p to the current destination.
+func (s *switchWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	w := s.w
+	s.mu.Unlock()
+	return w.Write(p)
+}
+
+// swap changes the destination, returning the previous one.
+func (s *switchWriter) swap(w io.Writer) io.Writer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.w
+	s.w = w
+	return prev
+}
+
+// Wait waits for the tasks started with Go, stops the live region and
+// prints a summary. Tasks added with AddTask and still running are marked
+// as failed. The returned error joins the errors of the failed tasks.
+func (g *TaskGroup) Wait() error {
+	g.wg.Wait()
+
+	g.mu.Lock()
+	for _, t := range g.tasks {
+		if t.state == types.TaskRunning {
+			t.state = types.TaskFailed
+			t.err = fmt.Errorf("not completed")
+			t.ended = time.Now()
+		}
+	}
+	g.mu.Unlock()
+
+	if g.program != nil {
+		g.program.Send(stopMsg{})
+	}
+	<-g.done
+	g.cancel()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, restore := range g.restores {
+		restore()
+	}
+	g.restores = nil
+	if len(g.partial) > 0 {
+		fmt.Fprintln(g.out, string(g.partial))
+		g.partial = nil
+	}
+
+	var errs []error
+	failed := 0
+	for _, t := range g.tasks {
+		if t.state == types.TaskFailed {
+			failed++
+			errs = append(errs, fmt.Errorf("%s: %w", t.name, t.err))
+		}
+		fmt.Fprintln(g.out, t.summaryLine())
+	}
+	fmt.Fprintf(
+		g.out, "%d tasks completed, %d failed in %s\n",
+		len(g.tasks)-failed, failed, formatElapsed(time.Since(g.started)),
+	)
+
+	return errors.Join(errs...)
+}
+
+// Name returns the name of the task.
+func (t *Task) Name() string {
+	return t.name
+}
+
+// State returns the current state of the task.
+func (t *Task) State() types.TaskState {
+	t.group.mu.Lock()
+	defer t.group.mu.Unlock()
+	return t.state
+}
+
+// Err returns the error the task failed with, if any.
+func (t *Task) Err() error {
+	t.group.mu.Lock()
+	defer t.group.mu.Unlock()
+	return t.err
+}
+
+// Elapsed returns the time spent on the task so far.
+func (t *Task) Elapsed() time.Duration {
+	t.group.mu.Lock()
+	defer t.group.mu.Unlock()
+	return t.elapsed()
+}
+
+func (t *Task) elapsed() time.Duration {
+	if t.ended.IsZero() {
+		return time.Since(t.started)
+	}
+	return t.ended.Sub(t.started)
+}
+
+// SetStatus updates the status message shown next to the task.
+func (t *Task) SetStatus(status string) {
+	t.group.mu.Lock()
+	defer t.group.mu.Unlock()
+	t.status = status
+	if t.group.plain != nil {
+		fmt.Fprintf(t.group.plain, "%s: %s\n", t.name, status)
+	}
+}
+
+// Increment advances the progress bar of the task by inc steps.
+func (t *Task) Increment(inc int) {
+	t.group.mu.Lock()
+	defer t.group.mu.Unlock()
+	t.current = min(t.current+inc, t.total)
+	if t.group.plain != nil && t.total > 0 {
+		step := t.current * 10 / t.total
+		if step > t.lastStep {
+			t.lastStep = step
+			fmt.Fprintf(t.group.plain, "%s %d%%\n", t.name, step*10)
+		}
+	}
+}
+
+// Done marks the task as completed successfully.
+func (t *Task) Done() {
+	t.finish(types.TaskDone, nil)
+}
+
+// Fail marks the task as failed with the given error.
+func (t *Task) Fail(err error) {
+	t.finish(types.TaskFailed, err)
+}
+
+func (t *Task) finish(state types.TaskState, err error) {
+	t.group.mu.Lock()
+	defer t.group.mu.Unlock()
+	if t.state != types.TaskRunning {
+		return
+	}
+	t.state = state
+	t.err = err
+	t.ended = time.Now()
+	if state == types.TaskDone && t.total > 0 {
+		t.current = t.total
+	}
+	if t.group.plain != nil {
+		fmt.Fprintln(t.group.plain, t.summaryLine())
+	}
+}
+
+// summaryLine formats the final state of the task, mu must be held.
+func (t *Task) summaryLine() string {
+	elapsed := taskElapsedStyle.Render("(" + formatElapsed(t.elapsed()) + ")")
+	if t.state == types.TaskFailed {
+		return fmt.Sprintf("%s %s: %v %s", errorStyle.Render("✗"), t.name, t.err, elapsed)
+	}
+	return fmt.Sprintf("%s %s %s", taskDoneStyle.Render("✓"), t.name, elapsed)
+}
+
+// formatElapsed rounds a duration to a readable precision.
+func formatElapsed(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(100 * time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
+
+func (m taskGroupComponent) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+func (m taskGroupComponent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			m.group.cancel()
+		}
+	case taskGroupPrintMsg:
+		// the quit waits for the pending messages to be printed, the
+		// ones received while stopping are left to the sender
+		if m.quitting {
+			return m, nil
+		}
+		close(msg.printed)
+		m.pending++
+		return m, tea.Sequence(tea.Println(msg.line), func() tea.Msg { return taskGroupPrintedMsg{} })
+	case taskGroupPrintedMsg:
+		m.pending--
+		return m.quit()
+	case stopMsg:
+		m.quitting = true
+		return m.quit()
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// quit exits the program if it is stopping and no message is waiting to
+// be printed.
+func (m taskGroupComponent) quit() (tea.Model, tea.Cmd) {
+	if !m.quitting || m.pending > 0 {
+		return m, nil
+	}
+	return m, tea.Quit
+}
+
+func (m taskGroupComponent) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	m.group.mu.Lock()
+	defer m.group.mu.Unlock()
+
+	var s strings.Builder
+	s.WriteString("\n")
+	if m.group.title != "" {
+		s.WriteString(" " + lipgloss.NewStyle().Bold(true).Render(m.group.title) + "\n\n")
+	}
+
+	width := 0
+	for _, t := range m.group.tasks {
+		width = max(width, lipgloss.Width(t.name))
+	}
+
+	for _, t := range m.group.tasks {
+		var icon string
+		switch t.state {
+		case types.TaskDone:
+			icon = taskDoneStyle.Render("✓")
+		case types.TaskFailed:
+			icon = errorStyle.Render("✗")
+		default:
+			icon = m.spinner.View()
+		}
+
+		line := fmt.Sprintf(" %s %-*s", icon, width, t.name)
+		if t.total > 0 {
+			line += "  " + m.bar.ViewAs(float64(t.current)/float64(t.total))
+		}
+		if t.state == types.TaskFailed && t.err != nil {
+			line += "  " + errorStyle.Render(t.err.Error())
+		} else if t.status != "" {
+			line += "  " + t.status
+		}
+		line += "  " + taskElapsedStyle.Render(formatElapsed(t.elapsed()))
+		s.WriteString(line + "\n")
+	}
+
+	if m.group.ctx.Err() != nil {
+		s.WriteString(helpStyle.Render("interrupting...") + "\n")
+	}
+	return s.String()
+}
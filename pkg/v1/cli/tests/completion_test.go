@@ -0,0 +1,189 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+)
+
+type gadgetCmd struct {
+	cli.Base
+	Gadget string `arg:"" help:"The gadget to use"`
+	Target string `cli:"target,t" help:"Who to use it on"`
+	Silent bool   `cli:"silent,s" help:"Do not make noise"`
+}
+
+func (c *gadgetCmd) Complete(flag string, args []string, toComplete string) []string {
+	if flag == "target" {
+		return []string{"Joker", "Penguin\tA bird-themed villain"}
+	}
+	return []string{"batarang", "batclaw"}
+}
+
+type completionRootCmd struct {
+	cli.Base
+	Use  gadgetCmd `cmd:"use" help:"Use a gadget"`
+	Call struct {
+		cli.Base
+	} `cmd:"call" help:"Call Alfred"`
+}
+
+func completionValues(suggestions []string) []string {
+	values := []string{}
+	for _, s := range suggestions {
+		value, _, _ := strings.Cut(s, "\t")
+		values = append(values, value)
+	}
+	return values
+}
+
+func TestComplete(t *testing.T) {
+	cmd, err := cli.NewCommandFromStruct(&completionRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		words    []string
+		expected []string
+	}{
+		{[]string{""}, []string{"call", "completion", "help", "man", "use"}},
+		{[]string{"c"}, []string{"call", "completion"}},
+		{[]string{"use", ""}, []string{"batarang", "batclaw"}},
+		{[]string{"use", "--silent", "batc"}, []string{"batclaw"}},
+		{[]string{"use", "--t"}, []string{"--target"}},
+		{[]string{"use", "-t", ""}, []string{"Joker", "Penguin"}},
+		{[]string{"use", "--target=J"}, []string{"--target=Joker"}},
+		{[]string{"--output", "j"}, []string{"json"}},
+		{[]string{"completion", ""}, []string{"bash", "zsh", "fish"}},
+		{[]string{"call", ""}, []string{}},
+		{[]string{"help", "u"}, []string{"use"}},
+	}
+
+	for _, test := range tests {
+		got := completionValues(cmd.Complete(test.words))
+		if !slices.Equal(got, test.expected) {
+			t.Errorf("Complete(%q) = %q, expected %q", test.words, got, test.expected)
+		}
+	}
+}
+
+func TestCompletionScripts(t *testing.T) {
+	cmd, err := cli.NewCommandFromStruct(&completionRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetName("batctl")
+
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		oldArgs := os.Args
+		os.Args = []string{"batctl", "completion", shell}
+
+		var buf bytes.Buffer
+		cmd.SetOutput(&buf)
+		err := cmd.Execute()
+		os.Args = oldArgs
+		if err != nil {
+			t.Fatalf("%s: %v", shell, err)
+		}
+
+		script := buf.String()
+		if !strings.Contains(script, "__complete") {
+			t.Errorf("%s: the script does not call back the program:\n%s", shell, script)
+		}
+		if !strings.Contains(script, "batctl") {
+			t.Errorf("%s: the script does not reference the program:\n%s", shell, script)
+		}
+	}
+
+	if err := cmd.GenerateCompletion(&bytes.Buffer{}, "powershell"); err == nil {
+		t.Error("expected an error for an unsupported shell")
+	}
+}
+
+func TestBashCompletionFlagValue(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash is not installed")
+	}
+
+	cmd, err := cli.NewCommandFromStruct(&completionRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetName("batctl")
+	var script bytes.Buffer
+	if err := cmd.GenerateCompletion(&script, "bash"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the stub records the words it is called with and answers like
+	// "batctl __complete use --target=Jo" would
+	dir := t.TempDir()
+	stub := "#!/bin/sh\necho \"$@\" > \"$(dirname \"$0\")/args\"\nprintf -- '--target=Joker\\n--target=Penguin\\tA bird-themed villain\\n'\n"
+	if err := os.WriteFile(filepath.Join(dir, "batctl"), []byte(stub), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "completion.bash"), script.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	line := "batctl use --target=Jo"
+	run := exec.Command(bash, "-c", `source completion.bash
+COMP_LINE="$1" COMP_POINT=${#1}
+COMP_WORDS=(batctl use --target = Jo) COMP_CWORD=4
+_batctl_completions
+printf '%s\n' "${COMPREPLY[@]}"`, "bash", line)
+	run.Dir = dir
+	run.Env = append(os.Environ(), "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	out, err := run.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v: %s", err, out)
+	}
+
+	if string(out) != "Joker\n" {
+		t.Errorf("expected the value after \"=\" to be completed, got %q", out)
+	}
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(args) != "__complete use --target=Jo\n" {
+		t.Errorf("expected --target=Jo to reach the program as one word, got %q", args)
+	}
+}
+
+func TestCompleteCommand(t *testing.T) {
+	cmd, err := cli.NewCommandFromStruct(&completionRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	os.Args = []string{"batctl", "__complete", "use", "--target", "P"}
+
+	var buf bytes.Buffer
+	cmd.SetOutput(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != "Penguin\tA bird-themed villain\n" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
@@ -0,0 +1,63 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+	"github.com/vanilla-os/sdk/pkg/v1/fs"
+)
+
+func TestRenderDiff(t *testing.T) {
+	diff := fs.DiffText("a/motd", "b/motd", "Welcome\nto Gotham\n", "Welcome\nto Arkham\n", fs.DefaultDiffContext)
+
+	var plain bytes.Buffer
+	if err := cli.RenderDiff(&plain, diff, false); err != nil {
+		t.Fatal(err)
+	}
+	if plain.String() != diff.Unified() {
+		t.Errorf("expected the unified diff, got %q", plain.String())
+	}
+
+	var color bytes.Buffer
+	if err := cli.RenderDiff(&color, diff, true); err != nil {
+		t.Fatal(err)
+	}
+	out := color.String()
+	for _, want := range []string{"--- a/motd", "@@ -1,2 +1,2 @@", "2   │", "  2 │", "-to Gotham", "+to Arkham"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the rendered diff:\n%s", want, out)
+		}
+	}
+}
+
+func TestDiffCommandPipe(t *testing.T) {
+	type RootCmd struct {
+		cli.Base
+	}
+
+	cmd, err := cli.NewCommandFromStruct(&RootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	cmd.SetOutput(&buf)
+
+	diff := fs.DiffText("a", "b", "one\n", "two\n", fs.DefaultDiffContext)
+	if err := cmd.Diff(diff); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != diff.Unified() {
+		t.Errorf("expected the unified diff when not on a terminal, got %q", buf.String())
+	}
+}
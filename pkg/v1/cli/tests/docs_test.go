@@ -0,0 +1,126 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+)
+
+func (c *gadgetCmd) Examples() []string {
+	return []string{"batctl use batarang --target Joker"}
+}
+
+func newDocsCommand(t *testing.T) *cli.Command {
+	t.Setenv("SOURCE_DATE_EPOCH", "0")
+
+	cmd, err := cli.NewCommandFromStruct(&completionRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetName("batctl")
+	return cmd
+}
+
+func TestDocPages(t *testing.T) {
+	cmd := newDocsCommand(t)
+
+	var names []string
+	for _, page := range cmd.DocPages() {
+		names = append(names, page.Name)
+	}
+	expected := []string{"batctl", "batctl call", "batctl completion", "batctl help", "batctl man", "batctl use"}
+	if !slices.Equal(names, expected) {
+		t.Fatalf("unexpected pages %q", names)
+	}
+
+	use := cmd.DocPages()[5]
+	if use.Usage != "batctl use [flags] [<gadget>]" {
+		t.Errorf("unexpected usage %q", use.Usage)
+	}
+	if len(use.Flags) != 2 || use.Flags[0].Name != "silent" || !use.Flags[0].Boolean {
+		t.Errorf("unexpected flags %+v", use.Flags)
+	}
+	if len(use.InheritedFlags) == 0 {
+		t.Error("expected the global flags to be inherited")
+	}
+	if use.Parent == nil || use.Parent.FileName != "batctl" {
+		t.Errorf("unexpected parent %+v", use.Parent)
+	}
+}
+
+func TestRenderDoc(t *testing.T) {
+	cmd := newDocsCommand(t)
+
+	tests := []struct {
+		renderer cli.DocRenderer
+		expected []string
+	}{
+		{&cli.MarkdownRenderer{}, []string{
+			"# batctl use",
+			"| `-t, --target <value>` | Who to use it on |",
+			"batctl use batarang --target Joker",
+			"[batctl](batctl.md)",
+		}},
+		{&cli.HTMLRenderer{}, []string{
+			"<h1>batctl use</h1>",
+			"<code>-t, --target &lt;value&gt;</code>",
+			`<a href="batctl.html">batctl</a>`,
+		}},
+		{&cli.RoffRenderer{}, []string{
+			`.TH BATCTL-USE 1 "1970-01-01"`,
+			".SH SYNOPSIS",
+			`\fB-t, --target <value>\fP`,
+			".BR batctl (1)",
+		}},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := cmd.RenderDoc(&buf, test.renderer, "use"); err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range test.expected {
+			if !strings.Contains(buf.String(), expected) {
+				t.Errorf("%T: expected %q in:\n%s", test.renderer, expected, buf.String())
+			}
+		}
+	}
+
+	if err := cmd.RenderDoc(&bytes.Buffer{}, &cli.MarkdownRenderer{}, "fly"); err == nil {
+		t.Error("expected an error for an unknown command")
+	}
+}
+
+func TestGenerateDocs(t *testing.T) {
+	cmd := newDocsCommand(t)
+	dir := filepath.Join(t.TempDir(), "docs")
+
+	files, err := cmd.GenerateDocs(&cli.MarkdownRenderer{}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 6 {
+		t.Fatalf("expected 6 files, got %q", files)
+	}
+
+	root, err := os.ReadFile(filepath.Join(dir, "batctl.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(root), "[batctl use](batctl-use.md)") {
+		t.Errorf("expected a link to the subcommand page:\n%s", root)
+	}
+}
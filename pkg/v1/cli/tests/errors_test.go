@@ -0,0 +1,187 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+)
+
+var errBatteryLow = errors.New("battery low")
+
+var failure error
+
+type failCmd struct {
+	cli.Base
+	Force bool `cli:"force,f" help:"Ignore the battery level"`
+}
+
+func (c *failCmd) Run() error {
+	return failure
+}
+
+type failRootCmd struct {
+	cli.Base
+	Launch failCmd `cmd:"launch" help:"Launch the batplane"`
+}
+
+func executeFailing(t *testing.T, err error, args ...string) (error, string, string) {
+	t.Helper()
+	failure = err
+
+	cmd, cerr := cli.NewCommandFromStruct(&failRootCmd{})
+	if cerr != nil {
+		t.Fatal(cerr)
+	}
+	cmd.SetTranslator(func(s string) string {
+		if s == "batplane.grounded" {
+			return "Il batplano è a terra"
+		}
+		return s
+	})
+
+	var stdout, stderr bytes.Buffer
+	cmd.SetOutput(&stdout)
+	cmd.SetErrOutput(&stderr)
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	os.Args = append([]string{"batplane"}, args...)
+	return cmd.Execute(), stdout.String(), stderr.String()
+}
+
+func TestExecuteRendersErrors(t *testing.T) {
+	grounded := cli.WrapError(errBatteryLow, types.ExitTempFail, "pr:batplane.grounded").
+		WithHint("Charge it in the batcave")
+
+	err, _, stderr := executeFailing(t, grounded, "launch")
+	if !errors.Is(err, errBatteryLow) {
+		t.Errorf("expected the cause to be preserved, got %v", err)
+	}
+	if code := cli.ExitCode(err); code != types.ExitTempFail {
+		t.Errorf("expected exit code %d, got %d", types.ExitTempFail, code)
+	}
+
+	expected := "Error: Il batplano è a terra\n  battery low\nHint: Charge it in the batcave\n"
+	if stderr != expected {
+		t.Errorf("expected %q, got %q", expected, stderr)
+	}
+}
+
+func TestExecuteWrapsPlainErrors(t *testing.T) {
+	err, _, stderr := executeFailing(t, fmt.Errorf("cannot open the hangar: %w", fs.ErrPermission), "launch")
+
+	var cliErr *cli.Error
+	if !errors.As(err, &cliErr) || cliErr.Code != types.ExitNoPerm {
+		t.Errorf("expected an *Error with code %d, got %#v", types.ExitNoPerm, err)
+	}
+	if stderr != "Error: cannot open the hangar: permission denied\n" {
+		t.Errorf("unexpected error output %q", stderr)
+	}
+}
+
+func TestExecuteRendersJSONErrors(t *testing.T) {
+	_, _, stderr := executeFailing(t, cli.NewError(types.ExitConfig, "no flight plan"), "launch", "--output", "json")
+
+	for _, expected := range []string{`"code": 78`, `"message": "no flight plan"`} {
+		if !strings.Contains(stderr, expected) {
+			t.Errorf("expected %s in %s", expected, stderr)
+		}
+	}
+}
+
+func TestExecuteUsageErrors(t *testing.T) {
+	err, _, stderr := executeFailing(t, nil, "launch", "--altitude", "100")
+	if code := cli.ExitCode(err); code != types.ExitUsage {
+		t.Errorf("expected exit code %d, got %d (%v)", types.ExitUsage, code, err)
+	}
+	if stderr != "" {
+		t.Errorf("expected usage errors not to be rendered twice, got %q", stderr)
+	}
+	if !errors.Is(err, cli.ErrUsage) || !strings.Contains(err.Error(), "--altitude") {
+		t.Errorf("expected a usage error keeping the parser message, got %v", err)
+	}
+
+	// an error from the command is not a usage error, whatever it says
+	err, _, stderr = executeFailing(t, errors.New("unknown flag: --warp"), "launch")
+	if code := cli.ExitCode(err); code != types.ExitFailure {
+		t.Errorf("expected exit code %d, got %d (%v)", types.ExitFailure, code, err)
+	}
+	if stderr != "Error: unknown flag: --warp\n" {
+		t.Errorf("unexpected error output %q", stderr)
+	}
+}
+
+type runningRootCmd struct {
+	cli.Base
+	Force bool `cli:"force,f" help:"Ignore the battery level"`
+}
+
+func (c *runningRootCmd) Run() error {
+	return failure
+}
+
+func TestExecuteUsageErrorsWithMiddlewares(t *testing.T) {
+	for _, test := range []struct {
+		args []string
+		code types.ExitCode
+	}{
+		{[]string{"--altitude", "100"}, types.ExitUsage},
+		{[]string{"--force"}, types.ExitFailure},
+	} {
+		failure = errBatteryLow
+		cmd, err := cli.NewCommandFromStruct(&runningRootCmd{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmd.AddMiddleware(func(inv *cli.Invocation, next func() error) error {
+			return next()
+		})
+		cmd.SetErrOutput(&bytes.Buffer{})
+
+		oldArgs := os.Args
+		os.Args = append([]string{"batplane"}, test.args...)
+		err = cmd.Execute()
+		os.Args = oldArgs
+
+		if code := cli.ExitCode(err); code != test.code {
+			t.Errorf("%v: expected exit code %d, got %d (%v)", test.args, test.code, code, err)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	for _, test := range []struct {
+		err  error
+		code types.ExitCode
+	}{
+		{nil, types.ExitOK},
+		{errBatteryLow, types.ExitFailure},
+		{cli.NewError(types.ExitDataErr, "bad gadget"), types.ExitDataErr},
+		{fmt.Errorf("launch: %w", cli.NewError(types.ExitNoHost, "unknown hangar")), types.ExitNoHost},
+		{cli.ErrRootRequired, types.ExitNoPerm},
+		{cli.ErrContainerNotAllowed, types.ExitUnavailable},
+		{fmt.Errorf("select: %w", cli.ErrNonInteractive), types.ExitUsage},
+		{fs.ErrNotExist, types.ExitNoInput},
+		{context.Canceled, types.ExitInterrupted},
+	} {
+		if code := cli.ExitCode(test.err); code != test.code {
+			t.Errorf("expected exit code %d for %v, got %d", test.code, test.err, code)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+)
+
+type setupForm struct {
+	Hostname   string   `form:"text" prompt:"Choose a hostname" default:"batcave" validate:"required,min=3,nospaces"`
+	Desktop    string   `form:"select" prompt:"Pick a desktop" options:"GNOME,KDE" default:"GNOME"`
+	Extras     []string `form:"multiselect" prompt:"Extras" options:"Flatpak,Steam,Docker" max:"2"`
+	Encrypt    bool     `form:"confirm" prompt:"Encrypt the disk?"`
+	Passphrase string   `form:"password" prompt:"Passphrase" if:"Encrypt" json:"passphrase"`
+	Theme      string   `form:"text" prompt:"KDE theme" if:"Desktop=KDE" validate:"required"`
+}
+
+func init() {
+	cli.RegisterValidator("nospaces", func(value string) error {
+		if strings.Contains(value, " ") {
+			return fmt.Errorf("spaces are not allowed")
+		}
+		return nil
+	})
+}
+
+func TestFormDefaults(t *testing.T) {
+	cmd, _ := newNonInteractiveCommand(t)
+
+	var form setupForm
+	if err := cmd.RunForm(&form); err != nil {
+		t.Fatal(err)
+	}
+
+	if form.Hostname != "batcave" || form.Desktop != "GNOME" || form.Encrypt || len(form.Extras) != 0 {
+		t.Errorf("unexpected defaults: %+v", form)
+	}
+}
+
+func TestFormPrefilledValues(t *testing.T) {
+	cmd, _ := newNonInteractiveCommand(t)
+
+	form := setupForm{Hostname: "wayne manor"}
+	if err := cmd.RunForm(&form); err == nil {
+		t.Error("expected validation error for pre-filled value")
+	}
+
+	form = setupForm{Hostname: "wayne-manor", Encrypt: true}
+	if err := cmd.RunForm(&form); !errors.Is(err, cli.ErrNonInteractive) {
+		t.Errorf("expected missing passphrase error, got %v", err)
+	}
+}
+
+func TestFormAnswersFile(t *testing.T) {
+	cmd, _ := newNonInteractiveCommand(t)
+
+	answers := filepath.Join(t.TempDir(), "answers.yaml")
+	content := `hostname: gotham
+desktop: KDE
+extras: [Flatpak, Steam]
+encrypt: true
+passphrase: alfred
+theme: Breeze
+`
+	if err := os.WriteFile(answers, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetAnswersFile(answers)
+
+	var form setupForm
+	if err := cmd.RunForm(&form); err != nil {
+		t.Fatal(err)
+	}
+
+	if form.Hostname != "gotham" || form.Desktop != "KDE" || !form.Encrypt ||
+		form.Passphrase != "alfred" || form.Theme != "Breeze" ||
+		!slices.Equal(form.Extras, []string{"Flatpak", "Steam"}) {
+		t.Errorf("unexpected answers: %+v", form)
+	}
+}
+
+func TestFormInvalidAnswers(t *testing.T) {
+	tests := map[string]string{
+		"unknown option":   `{"desktop": "Xfce"}`,
+		"too many extras":  `{"extras": ["Flatpak", "Steam", "Docker"]}`,
+		"failed validator": `{"hostname": "bat cave"}`,
+		"too short":        `{"hostname": "bc"}`,
+		"missing required": `{"desktop": "KDE"}`,
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd, _ := newNonInteractiveCommand(t)
+
+			answers := filepath.Join(t.TempDir(), "answers.json")
+			if err := os.WriteFile(answers, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cmd.SetAnswersFile(answers)
+
+			var form setupForm
+			if err := cmd.RunForm(&form); err == nil {
+				t.Errorf("expected an error, got %+v", form)
+			}
+		})
+	}
+}
+
+func TestFormInvalidStruct(t *testing.T) {
+	cmd, _ := newNonInteractiveCommand(t)
+
+	var notAStruct string
+	if err := cmd.RunForm(&notAStruct); err == nil {
+		t.Error("expected an error for a non struct value")
+	}
+
+	var badCondition struct {
+		Name string `form:"text" if:"Missing"`
+	}
+	if err := cmd.RunForm(&badCondition); err == nil {
+		t.Error("expected an error for an unknown condition field")
+	}
+}
+
+func TestFormTranslatedOptions(t *testing.T) {
+	cmd, _ := newNonInteractiveCommand(t)
+	cmd.SetTranslator(func(s string) string {
+		return map[string]string{"Dark": "Scuro", "Light": "Chiaro"}[s]
+	})
+
+	var form struct {
+		Theme  string `form:"select" prompt:"Theme" options:"pr:Dark,pr:Light"`
+		Accent string `form:"text" prompt:"Accent" if:"Theme=Dark" validate:"required"`
+	}
+
+	answers := filepath.Join(t.TempDir(), "answers.yaml")
+	if err := os.WriteFile(answers, []byte("theme: Dark\naccent: black\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetAnswersFile(answers)
+	if err := cmd.RunForm(&form); err != nil {
+		t.Fatal(err)
+	}
+	if form.Theme != "Dark" || form.Accent != "black" {
+		t.Errorf("expected the untranslated option and the conditional step, got %+v", form)
+	}
+
+	if err := os.WriteFile(answers, []byte("theme: Scuro\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.RunForm(&form); err == nil {
+		t.Error("expected an error answering with a translated option")
+	}
+}
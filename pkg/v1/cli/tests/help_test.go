@@ -0,0 +1,131 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+	"github.com/vanilla-os/sdk/pkg/v1/roff"
+	"github.com/vanilla-os/sdk/pkg/v1/roff/types"
+)
+
+func newHelpCommand(t *testing.T) *cli.Command {
+	t.Helper()
+	t.Setenv("SOURCE_DATE_EPOCH", "0")
+
+	cmd, err := cli.NewCommandFromStruct(&manRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetName("batctl")
+	return cmd
+}
+
+func TestManPageRoundTrip(t *testing.T) {
+	cmd := newHelpCommand(t)
+
+	man, err := cmd.ManPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := roff.Parse(man)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var headings []string
+	for _, section := range doc.Children {
+		headings = append(headings, section.Text)
+	}
+	expected := []string{"NAME", "SYNOPSIS", "OPTIONS", "COMMANDS", "EXIT STATUS", "ENVIRONMENT", "FILES", "SEE ALSO"}
+	if !slices.Equal(headings, expected) {
+		t.Fatalf("unexpected sections %q", headings)
+	}
+
+	synopsis := doc.Children[1].Children[0]
+	if synopsis.Text != "batctl" || !slices.Equal(synopsis.Lines, []string{"[flags]", "[command]"}) {
+		t.Errorf("unexpected synopsis %+v", synopsis)
+	}
+
+	var subcommands []string
+	for _, node := range doc.Children[3].Children {
+		if node.Kind == types.NodeSubSection {
+			subcommands = append(subcommands, node.Text)
+		}
+	}
+	if !slices.Equal(subcommands, []string{"batctl completion", "batctl help", "batctl man", "batctl use"}) {
+		t.Errorf("unexpected subcommands %q", subcommands)
+	}
+
+	exit := doc.Children[4].Children[0]
+	if exit.Kind != types.NodeTable || exit.Rows[3][1] != "Permission denied" {
+		t.Errorf("unexpected exit status table %+v", exit)
+	}
+}
+
+func TestHelpCommand(t *testing.T) {
+	cmd := newHelpCommand(t)
+
+	var buf bytes.Buffer
+	cmd.SetOutput(&buf)
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	os.Args = []string{"batctl", "help", "use"}
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, expected := range []string{
+		"BATCTL-USE(1)",
+		"\nSYNOPSIS\n    batctl use [flags] [<gadget>]\n",
+		"\n    -t, --target <value>\n        Who to use it on\n",
+		"\nEXAMPLES\n    batctl use batarang --target Joker\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, `\f`) || strings.Contains(out, "\n.") {
+		t.Errorf("unexpected roff markup in:\n%s", out)
+	}
+}
+
+func TestHelpTopics(t *testing.T) {
+	cmd := newHelpCommand(t)
+
+	d := roff.NewDocument()
+	d.Heading(7, "batctl-gadgets", "Gadgets", time.Unix(0, 0))
+	d.Section(types.SectionDescription)
+	d.Text("Gadgets are stored in the utility belt.")
+	d.EndSection()
+	cmd.AddHelpTopic("gadgets", d.String())
+
+	var buf bytes.Buffer
+	cmd.SetOutput(&buf)
+	if err := cmd.ShowHelp("gadgets"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "\nDESCRIPTION\n    Gadgets are stored in the utility belt.\n") {
+		t.Errorf("unexpected topic output:\n%s", buf.String())
+	}
+
+	if err := cmd.ShowHelp("batmobile"); err == nil {
+		t.Error("expected an error for an unknown topic")
+	}
+}
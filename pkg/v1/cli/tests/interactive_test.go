@@ -0,0 +1,125 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+)
+
+func newNonInteractiveCommand(t *testing.T) (*cli.Command, *bytes.Buffer) {
+	type RootCmd struct {
+		cli.Base
+	}
+
+	cmd, err := cli.NewCommandFromStruct(&RootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var status bytes.Buffer
+	cmd.SetOutput(&bytes.Buffer{})
+	cmd.SetErrOutput(&status)
+	cmd.SetNoInput(true)
+	return cmd, &status
+}
+
+func TestNonInteractivePrompts(t *testing.T) {
+	cmd, _ := newNonInteractiveCommand(t)
+
+	if cmd.Interactive() {
+		t.Fatal("expected non-interactive mode")
+	}
+
+	confirm, err := cmd.ConfirmAction("Do you like Batman?", "Yes", "No", false)
+	if err != nil || confirm {
+		t.Errorf("expected default choice false, got %v (%v)", confirm, err)
+	}
+
+	cmd.SetAssumeYes(true)
+	confirm, err = cmd.ConfirmAction("Do you like Batman?", "Yes", "No", false)
+	if err != nil || !confirm {
+		t.Errorf("expected assumed yes, got %v (%v)", confirm, err)
+	}
+
+	text, err := cmd.PromptText("What is your name?", "Bruce Wayne")
+	if err != nil || text != "Bruce Wayne" {
+		t.Errorf("expected placeholder, got %q (%v)", text, err)
+	}
+
+	if _, err := cmd.PromptText("What is your name?", ""); !errors.Is(err, cli.ErrNonInteractive) {
+		t.Errorf("expected ErrNonInteractive, got %v", err)
+	}
+
+	if _, err := cmd.SelectOption("Pick a hero", []string{"Batman", "Robin"}); !errors.Is(err, cli.ErrNonInteractive) {
+		t.Errorf("expected ErrNonInteractive, got %v", err)
+	}
+}
+
+func TestNonInteractiveProgress(t *testing.T) {
+	cmd, status := newNonInteractiveCommand(t)
+
+	spinner := cmd.StartSpinner("Loading the batmobile...")
+	spinner.UpdateMessage("Loading the batcave...")
+	spinner.Stop()
+
+	bar := cmd.StartProgressBar("Preparing your hero...", 20)
+	for i := 0; i < 20; i++ {
+		bar.Increment(1)
+	}
+	bar.Stop()
+
+	lines := strings.Split(strings.TrimSpace(status.String()), "\n")
+	if len(lines) != 13 {
+		t.Fatalf("expected 13 status lines, got %d: %q", len(lines), status.String())
+	}
+	if lines[0] != "Loading the batmobile..." || lines[1] != "Loading the batcave..." {
+		t.Errorf("unexpected spinner lines: %q", lines[:2])
+	}
+	if lines[12] != "Preparing your hero... 100%" {
+		t.Errorf("unexpected last progress line: %q", lines[12])
+	}
+}
+
+func TestNonInteractiveExtendedPrompts(t *testing.T) {
+	cmd, _ := newNonInteractiveCommand(t)
+
+	noSpaces := func(value string) error {
+		if strings.Contains(value, " ") {
+			return errors.New("the hostname cannot contain spaces")
+		}
+		return nil
+	}
+
+	hostname, err := cmd.PromptTextWithValidation("Choose a hostname", "batcomputer", noSpaces)
+	if err != nil || hostname != "batcomputer" {
+		t.Errorf("expected placeholder, got %q (%v)", hostname, err)
+	}
+
+	if _, err := cmd.PromptTextWithValidation("Choose a hostname", "bat computer", noSpaces); err == nil {
+		t.Error("expected the invalid placeholder to be rejected")
+	}
+
+	if _, err := cmd.PromptPassword("Choose a password", true); !errors.Is(err, cli.ErrNonInteractive) {
+		t.Errorf("expected ErrNonInteractive, got %v", err)
+	}
+
+	gadgets, err := cmd.SelectMultipleOptions("Gadgets", []string{"Batarang", "Batclaw"}, 0, 0)
+	if err != nil || len(gadgets) != 0 {
+		t.Errorf("expected an empty selection, got %v (%v)", gadgets, err)
+	}
+
+	if _, err := cmd.SelectMultipleOptions("Gadgets", []string{"Batarang", "Batclaw"}, 1, 2); !errors.Is(err, cli.ErrNonInteractive) {
+		t.Errorf("expected ErrNonInteractive, got %v", err)
+	}
+}
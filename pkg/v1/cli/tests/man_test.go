@@ -0,0 +1,171 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mirkobrombin/go-cli-builder/v2/pkg/help"
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+)
+
+type manRootCmd struct {
+	cli.Base `man-exit:"0=Success;1=Generic failure;77=Permission denied" man-env:"BATCTL_HOME=Where the bat gadgets are stored" man-files:"/etc/batctl.yml=System configuration" man-see-also:"apx(1);https://vanillaos.org"`
+	Verbose  bool      `cli:"verbose,v" help:"Print more details" env:"BATCTL_VERBOSE"`
+	Use      gadgetCmd `cmd:"use" help:"Use a gadget"`
+}
+
+func TestManPageSections(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "0")
+
+	cmd, err := cli.NewCommandFromStruct(&manRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetName("batctl")
+
+	man, err := cmd.ManPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		`.TH BATCTL 1 "1970-01-01" "batctl"`,
+		".SH NAME\nbatctl \\-",
+		".SH SYNOPSIS\n.SY batctl\n[flags]\n[command]\n.YS",
+		".SS batctl use\n.SY \"batctl use\"",
+		".EX\nbatctl use batarang --target Joker\n.EE",
+		".SH EXIT STATUS\n.TS",
+		"77\tPermission denied",
+		".SH ENVIRONMENT",
+		"\\fBBATCTL_HOME\\fP",
+		"\\fBBATCTL_VERBOSE\\fP",
+		".SH FILES",
+		".SH SEE ALSO\n.BR apx (1)",
+		".UR https://vanillaos.org\n.UE",
+		"\\fB--output <value>\\fP",
+		".SS batctl completion",
+	} {
+		if !strings.Contains(man, expected) {
+			t.Errorf("expected %q in:\n%s", expected, man)
+		}
+	}
+
+	// mandoc -Tlint complains about blank lines, trailing spaces and
+	// paragraph macros right after a heading
+	if strings.Contains(man, "\n\n") {
+		t.Error("the page contains blank lines")
+	}
+	if regexp.MustCompile(`[ \t]\n`).MatchString(man) {
+		t.Error("the page contains trailing whitespace")
+	}
+	if regexp.MustCompile(`\.S[HS] [^\n]*\n\.(PP|LP|P)\n`).MatchString(man) {
+		t.Errorf("the page contains a paragraph right after a heading:\n%s", man)
+	}
+}
+
+func TestGenerateManPageTranslatesPlainMsgids(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "0")
+
+	italian := map[string]string{
+		"Use a gadget":       "Usa un gadget",
+		"Who to use it on":   "Su chi usarlo",
+		"Print more details": "Mostra più dettagli",
+	}
+	man, err := cli.GenerateManPage(&manRootCmd{}, func(key string) string {
+		if translated, ok := italian[key]; ok {
+			return translated
+		}
+		return key
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range italian {
+		if !strings.Contains(man, expected) {
+			t.Errorf("expected %q in:\n%s", expected, man)
+		}
+	}
+}
+
+func TestLocalizedManPages(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "0")
+
+	cmd, err := cli.NewCommandFromStruct(&manRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetName("batctl")
+
+	dir := t.TempDir()
+	files, err := cmd.GenerateManPages(filepath.Join(dir, "man"), map[string]help.Translator{
+		"it":    func(s string) string { return s },
+		"pt_BR": func(s string) string { return s },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 man pages, got %q", files)
+	}
+
+	it, err := os.ReadFile(filepath.Join(dir, "man", "it", "man1", "batctl.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{".SH NOME\nbatctl \\-", ".SH SINTASSI", ".SH OPZIONI\n", ".SH COMANDI", ".SH AMBIENTE", "lb lb\nl lx.\nCodice\tSignificato", ".SH VEDERE ANCHE"} {
+		if !strings.Contains(string(it), expected) {
+			t.Errorf("expected %q in:\n%s", expected, it)
+		}
+	}
+
+	installed, err := cli.InstallManPages(filepath.Join(dir, "man"), filepath.Join(dir, "usr", "share", "man"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rel []string
+	for _, path := range installed {
+		r, _ := filepath.Rel(dir, path)
+		rel = append(rel, r)
+	}
+	slices.Sort(rel)
+	expected := []string{
+		"usr/share/man/it/man1/batctl.1.gz",
+		"usr/share/man/man1/batctl.1.gz",
+		"usr/share/man/pt_BR/man1/batctl.1.gz",
+	}
+	if !slices.Equal(rel, expected) {
+		t.Fatalf("unexpected installed pages %q", rel)
+	}
+
+	f, err := os.Open(installed[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(page), ".TH BATCTL 1") {
+		t.Errorf("unexpected installed page:\n%s", page)
+	}
+}
@@ -0,0 +1,147 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+)
+
+type ctxKey struct{}
+
+var middlewareEvents []string
+
+type signalCmd struct {
+	cli.Base
+	Color string `cli:"color,c" help:"Color of the signal"`
+}
+
+func (c *signalCmd) Before() error {
+	middlewareEvents = append(middlewareEvents, "before")
+	return nil
+}
+
+func (c *signalCmd) Run() error {
+	middlewareEvents = append(middlewareEvents, "run "+c.Color+" "+c.Ctx.Value(ctxKey{}).(string))
+	return nil
+}
+
+func (c *signalCmd) After() error {
+	middlewareEvents = append(middlewareEvents, "after")
+	return nil
+}
+
+type middlewareRootCmd struct {
+	cli.Base
+	Verbose bool      `cli:"verbose,v" help:"Print more details"`
+	Signal  signalCmd `cmd:"signal" help:"Light the signal"`
+	Other   signalCmd `cmd:"other" help:"Another signal"`
+}
+
+func runMiddlewareCommand(t *testing.T, cmd *cli.Command, args ...string) error {
+	t.Helper()
+	middlewareEvents = nil
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	os.Args = append([]string{"batsignal"}, args...)
+	return cmd.Execute()
+}
+
+func TestMiddlewareChain(t *testing.T) {
+	cmd, err := cli.NewCommandFromStruct(&middlewareRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	cmd.AddMiddleware(
+		func(inv *cli.Invocation, next func() error) error {
+			verbose, _ := inv.Flag("verbose")
+			color, _ := inv.Flag("color")
+			middlewareEvents = append(middlewareEvents, "first", inv.Path, color.(string))
+			if verbose != true {
+				t.Errorf("expected the verbose flag to be bound, got %v", verbose)
+			}
+			inv.Context = context.WithValue(inv.Context, ctxKey{}, "gotham")
+			err := next()
+			middlewareEvents = append(middlewareEvents, "first done")
+			return err
+		},
+		cli.BeforeHook(func(inv *cli.Invocation) error {
+			middlewareEvents = append(middlewareEvents, "second")
+			return nil
+		}),
+		cli.ForCommands(cli.AfterHook(func(inv *cli.Invocation, err error) error {
+			paths = append(paths, inv.Path)
+			return err
+		}), "other"),
+	)
+
+	if err := runMiddlewareCommand(t, cmd, "-v", "signal", "--color", "yellow"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"first", "signal", "yellow", "second", "before", "run yellow gotham", "after", "first done"}
+	if !slices.Equal(middlewareEvents, expected) {
+		t.Errorf("got events %q, expected %q", middlewareEvents, expected)
+	}
+	if len(paths) != 0 {
+		t.Errorf("expected the restricted middleware to be skipped, got %q", paths)
+	}
+
+	if err := runMiddlewareCommand(t, cmd, "--verbose", "other"); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(paths, []string{"other"}) {
+		t.Errorf("expected the restricted middleware to run for other, got %q", paths)
+	}
+}
+
+func TestMiddlewareStopsCommand(t *testing.T) {
+	cmd, err := cli.NewCommandFromStruct(&middlewareRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	denied := errors.New("the signal is broken")
+	cmd.AddMiddleware(cli.BeforeHook(func(inv *cli.Invocation) error {
+		return denied
+	}))
+
+	if err := runMiddlewareCommand(t, cmd, "signal"); !errors.Is(err, denied) {
+		t.Errorf("expected %v, got %v", denied, err)
+	}
+	if len(middlewareEvents) != 0 {
+		t.Errorf("expected the command not to run, got %q", middlewareEvents)
+	}
+}
+
+func TestRequireRoot(t *testing.T) {
+	cmd, err := cli.NewCommandFromStruct(&middlewareRootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.AddMiddleware(cli.RequireRoot(), cli.BeforeHook(func(inv *cli.Invocation) error {
+		inv.Context = context.WithValue(inv.Context, ctxKey{}, "gotham")
+		return nil
+	}))
+
+	err = runMiddlewareCommand(t, cmd, "signal")
+	if os.Geteuid() == 0 && err != nil {
+		t.Errorf("expected the command to run as root, got %v", err)
+	}
+	if os.Geteuid() != 0 && !errors.Is(err, cli.ErrRootRequired) {
+		t.Errorf("expected %v, got %v", cli.ErrRootRequired, err)
+	}
+}
@@ -0,0 +1,121 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+)
+
+type hero struct {
+	Name     string `json:"name"`
+	RealName string `json:"real_name"`
+	Age      int    `json:"age"`
+	Secret   string `json:"-"`
+	Hidden   bool   `json:"hidden" output:"-"`
+}
+
+var heroes = []hero{
+	{Name: "Batman", RealName: "Bruce Wayne", Age: 35, Secret: "cave"},
+	{Name: "Robin", RealName: "Dick Grayson", Age: 25},
+}
+
+func TestRenderOutputJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := cli.RenderOutput(&buf, heroes, types.OutputJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"real_name": "Bruce Wayne"`) {
+		t.Errorf("unexpected JSON output: %s", out)
+	}
+	if strings.Contains(out, "cave") {
+		t.Errorf("json:\"-\" field leaked in output: %s", out)
+	}
+}
+
+func TestRenderOutputYAML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := cli.RenderOutput(&buf, heroes[0], types.OutputYAML); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "name: Batman\nreal_name: Bruce Wayne\nage: 35\nhidden: false\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestRenderOutputPlain(t *testing.T) {
+	var buf bytes.Buffer
+	if err := cli.RenderOutput(&buf, heroes, types.OutputPlain); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %q", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "NAME") || !strings.Contains(lines[0], "REAL NAME") {
+		t.Errorf("unexpected header: %q", lines[0])
+	}
+	if strings.Contains(lines[0], "HIDDEN") {
+		t.Errorf("output:\"-\" column leaked in header: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "Bruce Wayne") {
+		t.Errorf("unexpected row: %q", lines[1])
+	}
+}
+
+func TestOutputFlag(t *testing.T) {
+	type RootCmd struct {
+		cli.Base
+	}
+
+	cmd, err := cli.NewCommandFromStruct(&RootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	os.Args = []string{"test", "--output", "json"}
+
+	var buf bytes.Buffer
+	cmd.SetOutput(&buf)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+
+	format, err := cmd.OutputFormat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != types.OutputJSON {
+		t.Fatalf("expected json format, got %q", format)
+	}
+
+	if err := cmd.Output(heroes); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "[") {
+		t.Errorf("expected JSON output, got %q", buf.String())
+	}
+
+	if _, err := cli.ParseOutputFormat("xml"); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,180 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/phuslu/log"
+	"github.com/vanilla-os/sdk/pkg/v1/cli"
+	"github.com/vanilla-os/sdk/pkg/v1/cli/types"
+	"github.com/vanilla-os/sdk/pkg/v1/logs"
+	"golang.org/x/sys/unix"
+)
+
+func TestTaskGroupNonInteractive(t *testing.T) {
+	cmd, status := newNonInteractiveCommand(t)
+
+	var original bytes.Buffer
+	logger := &logs.Logger{
+		Term: log.Logger{
+			Writer: &log.ConsoleWriter{Writer: &original, EndWithMessage: true},
+		},
+	}
+
+	group := cmd.StartTaskGroup("Preparing the batmobile")
+	group.AttachLogger(logger)
+
+	engine := group.Go("engine", 10, func(ctx context.Context, task *cli.Task) error {
+		for i := 0; i < 10; i++ {
+			task.Increment(1)
+		}
+		logger.Term.Info().Msg("engine installed")
+		return nil
+	})
+	armor := group.Go("armor", 0, func(ctx context.Context, task *cli.Task) error {
+		task.SetStatus("polishing")
+		return errors.New("out of kevlar")
+	})
+	wheels := group.AddTask("wheels", 0)
+
+	err := group.Wait()
+	if err == nil || !strings.Contains(err.Error(), "out of kevlar") {
+		t.Errorf("expected the armor error, got %v", err)
+	}
+
+	if engine.State() != types.TaskDone || armor.State() != types.TaskFailed || wheels.State() != types.TaskFailed {
+		t.Errorf("unexpected states: %s, %s, %s", engine.State(), armor.State(), wheels.State())
+	}
+
+	out := status.String()
+	for _, expected := range []string{
+		"Preparing the batmobile",
+		"engine 100%",
+		"engine installed",
+		"armor: polishing",
+		"out of kevlar",
+		"1 tasks completed, 2 failed",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in output:\n%s", expected, out)
+		}
+	}
+
+	logger.Term.Info().Msg("after the group")
+	if !strings.Contains(original.String(), "after the group") || strings.Contains(original.String(), "engine installed") {
+		t.Errorf("expected the logger output to be restored, got %q", original.String())
+	}
+}
+
+// newTerminalCommand returns a command running in interactive mode on a
+// pseudo-terminal, whose output is discarded.
+func newTerminalCommand(t *testing.T) *cli.Command {
+	t.Helper()
+
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("no pseudo-terminal available: %v", err)
+	}
+	t.Cleanup(func() { master.Close() })
+	if err := unix.IoctlSetPointerInt(int(master.Fd()), unix.TIOCSPTLCK, 0); err != nil {
+		t.Skipf("no pseudo-terminal available: %v", err)
+	}
+	n, err := unix.IoctlGetInt(int(master.Fd()), unix.TIOCGPTN)
+	if err != nil {
+		t.Skipf("no pseudo-terminal available: %v", err)
+	}
+	terminal, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("no pseudo-terminal available: %v", err)
+	}
+	t.Cleanup(func() { terminal.Close() })
+	go io.Copy(io.Discard, master)
+
+	stdin := os.Stdin
+	os.Stdin = terminal
+	t.Cleanup(func() { os.Stdin = stdin })
+
+	type RootCmd struct {
+		cli.Base
+	}
+	cmd, err := cli.NewCommandFromStruct(&RootCmd{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.SetOutput(terminal)
+	cmd.SetErrOutput(&bytes.Buffer{})
+	if !cmd.Interactive() {
+		t.Skip("the pseudo-terminal is not detected as a terminal")
+	}
+	return cmd
+}
+
+func TestTaskGroupLogDuringWait(t *testing.T) {
+	cmd := newTerminalCommand(t)
+	logger := &logs.Logger{
+		Term: log.Logger{
+			Writer: &log.ConsoleWriter{Writer: io.Discard, EndWithMessage: true},
+		},
+	}
+
+	group := cmd.StartTaskGroup("Patrolling Gotham")
+	group.AttachLogger(logger)
+	group.Go("patrol", 0, func(ctx context.Context, task *cli.Task) error {
+		logger.Term.Info().Msg("all quiet")
+		return nil
+	})
+
+	// messages keep being logged while the group stops and after it
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				group.Println("still watching")
+				logger.Term.Info().Msg("still logging")
+			}
+		}()
+	}
+
+	finished := make(chan error)
+	go func() {
+		err := group.Wait()
+		time.Sleep(50 * time.Millisecond)
+		close(stop)
+		wg.Wait()
+		group.Println("after the group")
+		finished <- err
+	}()
+
+	select {
+	case err := <-finished:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("logging while the task group stops deadlocked")
+	}
+}
@@ -0,0 +1,87 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import "time"
+
+// DocPage is the documentation of a single command, as consumed by the
+// documentation renderers.
+type DocPage struct {
+	// Name is the full command path, e.g. "myapp config set"
+	Name string
+
+	// FileName is the base name of the page file, without extension,
+	// e.g. "myapp-config-set"
+	FileName string
+
+	// Program is the name of the root command
+	Program string
+
+	Description string
+	Long        string
+	Usage       string
+	Aliases     []string
+	Args        []DocArg
+	Flags       []DocFlag
+
+	// InheritedFlags are the flags declared by the parent commands
+	InheritedFlags []DocFlag
+
+	Subcommands []DocLink
+	Examples    []string
+
+	// ExitCodes, Environment, Files and SeeAlso fill the standard man
+	// page sections, see the man-* tags of cli.Base
+	ExitCodes   []DocEntry
+	Environment []DocEntry
+	Files       []DocEntry
+	SeeAlso     []string
+
+	// Parent links to the page of the parent command, nil for the root
+	Parent *DocLink
+
+	Date time.Time
+}
+
+// DocFlag documents a command flag.
+type DocFlag struct {
+	Name        string
+	Short       string
+	Description string
+	Default     string
+	Env         string
+	Required    bool
+
+	// Boolean flags do not take a value
+	Boolean bool
+}
+
+// DocArg documents a positional argument.
+type DocArg struct {
+	Name        string
+	Description string
+	Required    bool
+
+	// Variadic arguments accept any number of values
+	Variadic bool
+}
+
+// DocLink references the page of another command.
+type DocLink struct {
+	Name        string
+	FileName    string
+	Description string
+}
+
+// DocEntry is a name and description pair, such as an exit code or an
+// environment variable.
+type DocEntry struct {
+	Name        string
+	Description string
+}
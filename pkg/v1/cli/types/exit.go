@@ -0,0 +1,71 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+// ExitCode is the status a command line program terminates with. Codes
+// from 64 to 78 follow the conventions of sysexits.h.
+type ExitCode int
+
+const (
+	// ExitOK means the command completed successfully.
+	ExitOK ExitCode = 0
+
+	// ExitFailure is a generic failure.
+	ExitFailure ExitCode = 1
+
+	// ExitUsage means the command was used incorrectly, e.g. with a wrong
+	// number of arguments or an unknown flag.
+	ExitUsage ExitCode = 64
+
+	// ExitDataErr means the input data was incorrect.
+	ExitDataErr ExitCode = 65
+
+	// ExitNoInput means an input file did not exist or was not readable.
+	ExitNoInput ExitCode = 66
+
+	// ExitNoUser means the specified user did not exist.
+	ExitNoUser ExitCode = 67
+
+	// ExitNoHost means the specified host did not exist.
+	ExitNoHost ExitCode = 68
+
+	// ExitUnavailable means a service or requirement is unavailable.
+	ExitUnavailable ExitCode = 69
+
+	// ExitSoftware is an internal software error.
+	ExitSoftware ExitCode = 70
+
+	// ExitOSErr is an operating system error, e.g. a failed fork.
+	ExitOSErr ExitCode = 71
+
+	// ExitOSFile means a system file was missing or broken.
+	ExitOSFile ExitCode = 72
+
+	// ExitCantCreate means an output file could not be created.
+	ExitCantCreate ExitCode = 73
+
+	// ExitIOErr means an error occurred while doing I/O.
+	ExitIOErr ExitCode = 74
+
+	// ExitTempFail is a temporary failure, the user is invited to retry.
+	ExitTempFail ExitCode = 75
+
+	// ExitProtocol means a remote system returned something invalid.
+	ExitProtocol ExitCode = 76
+
+	// ExitNoPerm means the user has not sufficient permissions.
+	ExitNoPerm ExitCode = 77
+
+	// ExitConfig means something was found in an unconfigured or
+	// misconfigured state.
+	ExitConfig ExitCode = 78
+
+	// ExitInterrupted means the command was interrupted, e.g. by SIGINT.
+	ExitInterrupted ExitCode = 130
+)
@@ -0,0 +1,33 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+// OutputFormat is the format used to render structured data to the user.
+type OutputFormat string
+
+const (
+	// OutputAuto picks OutputTable on a terminal and OutputPlain otherwise.
+	OutputAuto OutputFormat = ""
+
+	// OutputTable renders data as a styled table.
+	OutputTable OutputFormat = "table"
+
+	// OutputJSON renders data as indented JSON.
+	OutputJSON OutputFormat = "json"
+
+	// OutputYAML renders data as YAML.
+	OutputYAML OutputFormat = "yaml"
+
+	// OutputPlain renders data as unstyled, tab separated text, suitable
+	// for pipes and scripts.
+	OutputPlain OutputFormat = "plain"
+)
+
+// OutputFormats lists all the formats accepted by the --output flag.
+var OutputFormats = []OutputFormat{OutputTable, OutputJSON, OutputYAML, OutputPlain}
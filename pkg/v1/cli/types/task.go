@@ -0,0 +1,36 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+// TaskState is the state of a task in a task group.
+type TaskState int
+
+const (
+	// TaskRunning is the state of a task in progress.
+	TaskRunning TaskState = iota
+
+	// TaskDone is the state of a task completed successfully.
+	TaskDone
+
+	// TaskFailed is the state of a task completed with an error.
+	TaskFailed
+)
+
+// String returns a human readable representation of the state.
+func (s TaskState) String() string {
+	switch s {
+	case TaskRunning:
+		return "running"
+	case TaskDone:
+		return "done"
+	case TaskFailed:
+		return "failed"
+	}
+	return "unknown"
+}
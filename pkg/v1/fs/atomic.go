@@ -9,7 +9,13 @@ package fs
 */
 
 import (
+	"errors"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 
 	"golang.org/x/sys/unix"
 )
@@ -41,3 +47,187 @@ func AtomicSwap(sourcePath, destinationPath string) error {
 
 	return nil
 }
+
+// AtomicWriter writes a file atomically: the data is written to a temporary
+// file in the same directory, which replaces the target only when Close is
+// called, so readers see either the old or the new content, even after a
+// crash. It implements io.WriteCloser.
+type AtomicWriter struct {
+	path string
+	perm os.FileMode
+	temp *os.File
+	done bool
+}
+
+// NewAtomicWriter returns an AtomicWriter replacing the file at path. If
+// path is a symlink, its target is replaced. The mode and, when permitted,
+// the owner of an existing file are preserved, perm masked by the umask is
+// used for new files, as os.WriteFile does. Abort must be called if the
+// content is not complete, it is safe to defer it.
+//
+// Example:
+//
+//	w, err := fs.NewAtomicWriter("/etc/batcave.conf", 0644)
+//	if err != nil {
+//		return err
+//	}
+//	defer w.Abort()
+//	if _, err := io.Copy(w, resp.Body); err != nil {
+//		return err
+//	}
+//	return w.Close()
+func NewAtomicWriter(path string, perm os.FileMode) (*AtomicWriter, error) {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-")
+	if err != nil {
+		return nil, err
+	}
+	return &AtomicWriter{path: path, perm: perm, temp: temp}, nil
+}
+
+// Name returns the path of the file being replaced.
+//
+// Example:
+//
+//	fmt.Printf("Writing %s\n", w.Name())
+func (w *AtomicWriter) Name() string {
+	return w.path
+}
+
+// Write writes data to the temporary file.
+//
+// Example:
+//
+//	_, err := w.Write([]byte("signal = on\n"))
+func (w *AtomicWriter) Write(p []byte) (int, error) {
+	if w.done {
+		return 0, os.ErrClosed
+	}
+	return w.temp.Write(p)
+}
+
+// Close flushes the temporary file to disk, applies the mode and, when
+// permitted, the owner of the file being replaced, renames it over the
+// file and flushes the directory, making the replacement durable. On
+// failure the temporary file is removed and the file left untouched.
+//
+// Example:
+//
+//	if err := w.Close(); err != nil {
+//		return err
+//	}
+func (w *AtomicWriter) Close() error {
+	if w.done {
+		return os.ErrClosed
+	}
+	w.done = true
+
+	if err := w.commit(); err != nil {
+		w.temp.Close()
+		os.Remove(w.temp.Name())
+		return err
+	}
+	return nil
+}
+
+// commit replaces the file with the temporary one.
+func (w *AtomicWriter) commit() error {
+	var mode os.FileMode
+	existing, err := os.Stat(w.path)
+	switch {
+	case err == nil:
+		mode = existing.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky)
+		if st, ok := existing.Sys().(*syscall.Stat_t); ok {
+			// the owner is kept only when permitted, a user can replace a
+			// file it can write but does not own
+			err := w.temp.Chown(int(st.Uid), int(st.Gid))
+			if err != nil && !errors.Is(err, fs.ErrPermission) {
+				return err
+			}
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		mode = w.perm &^ umask()
+	default:
+		return err
+	}
+
+	// the mode is set after the owner, as chown clears the setuid bit
+	if err := w.temp.Chmod(mode); err != nil {
+		return err
+	}
+	if err := w.temp.Sync(); err != nil {
+		return err
+	}
+	if err := w.temp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(w.temp.Name(), w.path); err != nil {
+		return err
+	}
+	return syncDir(filepath.Dir(w.path))
+}
+
+// Abort discards the temporary file, leaving the file untouched. It does
+// nothing if the writer was already closed.
+//
+// Example:
+//
+//	defer w.Abort()
+func (w *AtomicWriter) Abort() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+	w.temp.Close()
+	return os.Remove(w.temp.Name())
+}
+
+// AtomicWriteFile writes data to a file atomically and durably, as
+// AtomicWriter does: after a crash the file holds either the old or the
+// new content, never a truncated one. The mode and, when permitted, the
+// owner of an existing file are preserved, perm masked by the umask is
+// used for new files.
+//
+// Example:
+//
+//	err := fs.AtomicWriteFile("/etc/batcave.conf", []byte("signal = on\n"), 0644)
+//	if err != nil {
+//		fmt.Printf("Error writing file: %v", err)
+//		return
+//	}
+func AtomicWriteFile(path string, data []byte, perm os.FileMode) error {
+	w, err := NewAtomicWriter(path, perm)
+	if err != nil {
+		return err
+	}
+	defer w.Abort()
+
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// umask returns the file mode creation mask of the process. It is read
+// from /proc, since reading it with umask(2) changes it for a moment for
+// all the threads.
+func umask() os.FileMode {
+	status, err := os.ReadFile("/proc/self/status")
+	if err == nil {
+		for _, line := range strings.Split(string(status), "\n") {
+			if value, ok := strings.CutPrefix(line, "Umask:"); ok {
+				if mask, err := strconv.ParseUint(strings.TrimSpace(value), 8, 32); err == nil {
+					return os.FileMode(mask)
+				}
+			}
+		}
+	}
+
+	// kernels older than 4.7 do not report it
+	mask := unix.Umask(0)
+	unix.Umask(mask)
+	return os.FileMode(mask)
+}
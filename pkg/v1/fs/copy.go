@@ -0,0 +1,610 @@
+package fs
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Streaming, metadata preserving copy of files and trees.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+	"golang.org/x/sys/unix"
+)
+
+// copyChunkSize is the maximum size copied at once, so that the context
+// and the progress are checked regularly while copying large files.
+const copyChunkSize = 8 << 20
+
+// copyBufferSize is the size of the buffer used when the kernel cannot
+// copy the data by itself.
+const copyBufferSize = 1 << 20
+
+// CopyFile copies a file to another path, replacing it if it exists. The
+// data is streamed, cloned by the filesystem or copied in the kernel when
+// possible, and holes of sparse files are preserved. Symlinks are
+// followed, while FIFOs and devices are recreated. The mode, the
+// timestamps, the extended attributes and, when permitted, the owner are
+// preserved. The destination is replaced only once the copy is complete,
+// and copying a file to itself is an error.
+//
+// Example:
+//
+//	err := fs.CopyFile("/tmp/batman", "/tmp/robin")
+//	if err != nil {
+//		fmt.Printf("Error copying file: %v", err)
+//		return
+//	}
+func CopyFile(sourcePath, destinationPath string) error {
+	return CopyFileWithOptions(context.Background(), sourcePath, destinationPath, types.CopyOptions{
+		FollowSymlinks: true,
+	})
+}
+
+// CopyFileWithOptions copies a file as CopyFile does, with options and a
+// context to cancel the copy. A partially copied file is removed. Unlike
+// CopyFile, a symlink is copied as a symlink unless FollowSymlinks is set,
+// as CopyTree does. The Include and Exclude options are ignored.
+//
+// Example:
+//
+//	err := fs.CopyFileWithOptions(ctx, "/tmp/batcave.img", "/mnt/backup/batcave.img", types.CopyOptions{
+//		NoOverwrite: true,
+//		Progress: func(p types.CopyProgress) {
+//			fmt.Printf("\r%d/%d bytes", p.BytesCopied, p.BytesTotal)
+//		},
+//	})
+func CopyFileWithOptions(ctx context.Context, sourcePath, destinationPath string, options types.CopyOptions) error {
+	c := newCopier(ctx, options)
+	info, err := c.stat(sourcePath)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return &os.PathError{Op: "copy", Path: sourcePath, Err: syscall.EISDIR}
+	}
+
+	c.progress.FilesTotal = 1
+	if info.Mode().IsRegular() {
+		c.progress.BytesTotal = info.Size()
+	}
+	return c.copyEntry(sourcePath, destinationPath, info)
+}
+
+// CopyTree copies a directory and its content to another path, as CopyFile
+// does for each entry. The destination is created if missing, otherwise
+// the content is copied into it. Hard links within the tree are kept as
+// hard links. The metadata of the directories is applied once their
+// content is copied, so read-only directories are copied too.
+//
+// Example:
+//
+//	err := fs.CopyTree(ctx, "/etc/batcave", "/var/backups/batcave", types.CopyOptions{
+//		Exclude: []string{"*.swp", "cache"},
+//		Progress: func(p types.CopyProgress) {
+//			fmt.Printf("\r%d/%d files", p.FilesCopied, p.FilesTotal)
+//		},
+//	})
+//	if err != nil {
+//		fmt.Printf("Error copying tree: %v", err)
+//		return
+//	}
+func CopyTree(ctx context.Context, sourcePath, destinationPath string, options types.CopyOptions) error {
+	c := newCopier(ctx, options)
+	c.links = make(map[fileID]string)
+
+	info, err := c.stat(sourcePath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return CopyFileWithOptions(ctx, sourcePath, destinationPath, options)
+	}
+	if isSubPath(sourcePath, destinationPath) {
+		return fmt.Errorf("cannot copy %s into itself", sourcePath)
+	}
+
+	if options.Progress != nil {
+		err := c.walk(sourcePath, ".", func(_, _ string, info os.FileInfo) error {
+			if !info.IsDir() {
+				c.progress.FilesTotal++
+			}
+			if info.Mode().IsRegular() {
+				c.progress.BytesTotal += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	type copiedDir struct {
+		src, dst string
+		info     os.FileInfo
+	}
+	var dirs []copiedDir
+	err = c.walk(sourcePath, ".", func(src, rel string, info os.FileInfo) error {
+		dst := filepath.Join(destinationPath, filepath.FromSlash(rel))
+		if !info.IsDir() {
+			return c.copyEntry(src, dst, info)
+		}
+
+		if err := os.Mkdir(dst, 0700); errors.Is(err, fs.ErrExist) {
+			existing, err := os.Lstat(dst)
+			if err != nil {
+				return err
+			}
+			if !existing.IsDir() {
+				return &os.PathError{Op: "copy", Path: dst, Err: syscall.ENOTDIR}
+			}
+			// the content must be writable until the metadata is applied
+			if err := os.Chmod(dst, existing.Mode().Perm()|0700); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+		dirs = append(dirs, copiedDir{src, dst, info})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the deepest directories come last, their metadata is applied first
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := c.copyMetadata(dirs[i].src, dirs[i].dst, dirs[i].info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSubPath reports whether target is path itself or is inside it.
+func isSubPath(path, target string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absPath, absTarget)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+// matchesPattern reports whether a file matches one of the patterns, by
+// name or by its slash separated relative path.
+func matchesPattern(name, rel string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if pattern == name || pattern == rel {
+			return true
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fileID identifies a file across the mounted filesystems.
+type fileID struct {
+	dev, ino uint64
+}
+
+// statID returns the identifier of a file, if available.
+func statID(info os.FileInfo) (fileID, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileID{}, false
+	}
+	return fileID{dev: uint64(st.Dev), ino: st.Ino}, true
+}
+
+// copier holds the state of a copy.
+type copier struct {
+	ctx      context.Context
+	options  types.CopyOptions
+	progress types.CopyProgress
+
+	// links maps the files with more than one hard link to their first
+	// copy, nil if hard links are not preserved
+	links map[fileID]string
+
+	// visiting holds the directories being walked, to detect symlink loops
+	visiting map[fileID]bool
+
+	buffer []byte
+}
+
+// newCopier returns a copier for a single copy.
+func newCopier(ctx context.Context, options types.CopyOptions) *copier {
+	return &copier{
+		ctx:      ctx,
+		options:  options,
+		visiting: make(map[fileID]bool),
+	}
+}
+
+// stat returns the information of a file, following the symlinks if
+// requested.
+func (c *copier) stat(path string) (os.FileInfo, error) {
+	if c.options.FollowSymlinks {
+		return os.Stat(path)
+	}
+	return os.Lstat(path)
+}
+
+// walk calls fn for a file and, if it is a directory, for its content,
+// skipping the filtered entries. Parents are visited before their content.
+func (c *copier) walk(src, rel string, fn func(src, rel string, info os.FileInfo) error) error {
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+	info, err := c.stat(src)
+	if err != nil {
+		return err
+	}
+
+	if rel != "." {
+		name := path.Base(rel)
+		if matchesPattern(name, rel, c.options.Exclude) {
+			return nil
+		}
+		if !info.IsDir() && len(c.options.Include) > 0 && !matchesPattern(name, rel, c.options.Include) {
+			return nil
+		}
+	}
+	if err := fn(src, rel, info); err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return nil
+	}
+
+	if id, ok := statID(info); ok {
+		if c.visiting[id] {
+			return fmt.Errorf("symlink loop at %s", src)
+		}
+		c.visiting[id] = true
+		defer delete(c.visiting, id)
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := c.walk(filepath.Join(src, entry.Name()), path.Join(rel, entry.Name()), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyEntry copies a single file, which is not a directory. The copy is
+// made next to the destination and renamed over it once complete, so an
+// existing destination is only replaced by a complete copy.
+func (c *copier) copyEntry(src, dst string, info os.FileInfo) error {
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+	if err := c.checkDestination(src, dst, info); err != nil {
+		return err
+	}
+
+	// the other links of a file already copied are linked to its copy
+	st, _ := info.Sys().(*syscall.Stat_t)
+	id, hasID := statID(info)
+	if c.links != nil && hasID && st.Nlink > 1 {
+		if first, ok := c.links[id]; ok {
+			temp, err := createTemp(dst, func(temp string) error {
+				return os.Link(first, temp)
+			})
+			if err == nil {
+				if err := c.commit(temp, dst); err != nil {
+					return err
+				}
+				// renaming a link over another link of the same file
+				// does nothing, leaving the temporary one
+				os.Remove(temp)
+				if info.Mode().IsRegular() {
+					c.addBytes(src, info.Size())
+				}
+				c.fileDone(src)
+				return nil
+			}
+		}
+	}
+
+	var temp string
+	var err error
+	mode := info.Mode()
+	switch {
+	case mode.IsRegular():
+		temp, err = c.copyRegular(src, dst, info)
+	case mode&os.ModeSymlink != 0:
+		var target string
+		if target, err = os.Readlink(src); err == nil {
+			temp, err = createTemp(dst, func(temp string) error {
+				return os.Symlink(target, temp)
+			})
+		}
+	case st != nil:
+		// FIFOs, sockets and devices are recreated
+		temp, err = createTemp(dst, func(temp string) error {
+			if err := unix.Mknod(temp, st.Mode, int(st.Rdev)); err != nil {
+				return &os.PathError{Op: "mknod", Path: temp, Err: err}
+			}
+			return nil
+		})
+	default:
+		err = &os.PathError{Op: "copy", Path: src, Err: syscall.ENOTSUP}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := c.copyMetadata(src, temp, info); err != nil {
+		os.Remove(temp)
+		return err
+	}
+	if err := c.commit(temp, dst); err != nil {
+		return err
+	}
+	if c.links != nil && hasID && st.Nlink > 1 {
+		c.links[id] = dst
+	}
+	c.fileDone(src)
+	return nil
+}
+
+// checkDestination verifies that a file can be copied to dst: it must not
+// be the source itself nor a directory, and must not exist at all with the
+// NoOverwrite option.
+func (c *copier) checkDestination(src, dst string, info os.FileInfo) error {
+	existing, err := os.Lstat(dst)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if os.SameFile(info, existing) {
+		return fmt.Errorf("cannot copy %s to itself", src)
+	}
+	if c.options.NoOverwrite {
+		return &os.PathError{Op: "copy", Path: dst, Err: fs.ErrExist}
+	}
+	if existing.IsDir() {
+		return &os.PathError{Op: "copy", Path: dst, Err: syscall.EISDIR}
+	}
+	return nil
+}
+
+// commit renames a complete copy over its destination, removing it if it
+// fails.
+func (c *copier) commit(temp, dst string) error {
+	if err := rename(temp, dst, c.options.NoOverwrite); err != nil {
+		os.Remove(temp)
+		return err
+	}
+	return nil
+}
+
+// createTemp creates a file with a temporary name next to dst by calling
+// create, and returns its path. Other names are tried if one exists.
+func createTemp(dst string, create func(temp string) error) (string, error) {
+	prefix := filepath.Join(filepath.Dir(dst), "."+filepath.Base(dst)+".tmp-")
+	for range 100 {
+		temp := prefix + strconv.FormatUint(rand.Uint64(), 36)
+		err := create(temp)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return temp, nil
+	}
+	return "", &os.PathError{Op: "copy", Path: dst, Err: fs.ErrExist}
+}
+
+// copyRegular copies the data of a regular file to a temporary file next
+// to dst and returns its path. The temporary file is removed on failure.
+func (c *copier) copyRegular(src, dst string, info os.FileInfo) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	var out *os.File
+	temp, err := createTemp(dst, func(temp string) error {
+		var err error
+		out, err = os.OpenFile(temp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := c.copyData(in, out, info.Size()); err != nil {
+		out.Close()
+		os.Remove(temp)
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(temp)
+		return "", err
+	}
+	return temp, nil
+}
+
+// copyData copies size bytes from in to out: the data is cloned if the
+// filesystem supports reflinks, otherwise only the data segments are
+// copied, so that the holes of sparse files are preserved.
+func (c *copier) copyData(in, out *os.File, size int64) error {
+	if size == 0 {
+		return nil
+	}
+	if unix.IoctlFileClone(int(out.Fd()), int(in.Fd())) == nil {
+		c.addBytes(in.Name(), size)
+		return nil
+	}
+
+	useRange := true
+	var offset int64
+	for offset < size {
+		start, err := unix.Seek(int(in.Fd()), offset, unix.SEEK_DATA)
+		if errors.Is(err, unix.ENXIO) {
+			break // only a hole is left
+		}
+		if err != nil {
+			start = offset // holes are not supported
+		}
+		end, err := unix.Seek(int(in.Fd()), start, unix.SEEK_HOLE)
+		if err != nil || end > size {
+			end = size
+		}
+
+		c.addBytes(in.Name(), start-offset)
+		if err := c.copyRange(in, out, start, end, &useRange); err != nil {
+			return err
+		}
+		offset = end
+	}
+	c.addBytes(in.Name(), size-offset)
+
+	// a trailing hole is restored by setting the size
+	return out.Truncate(size)
+}
+
+// copyRange copies the data between start and end, with copy_file_range
+// if supported, otherwise reading and writing it.
+func (c *copier) copyRange(in, out *os.File, start, end int64, useRange *bool) error {
+	for start < end {
+		if err := c.ctx.Err(); err != nil {
+			return err
+		}
+		n := min(end-start, copyChunkSize)
+
+		if *useRange {
+			roff, woff := start, start
+			written, err := unix.CopyFileRange(int(in.Fd()), &roff, int(out.Fd()), &woff, int(n), 0)
+			switch {
+			case err == nil && written == 0:
+				return nil // the file was truncated in the meantime
+			case err == nil:
+				start += int64(written)
+				c.addBytes(in.Name(), int64(written))
+				continue
+			case errors.Is(err, unix.ENOSYS), errors.Is(err, unix.EXDEV), errors.Is(err, unix.EINVAL),
+				errors.Is(err, unix.EOPNOTSUPP), errors.Is(err, unix.EPERM):
+				*useRange = false
+			default:
+				return &os.PathError{Op: "copy_file_range", Path: in.Name(), Err: err}
+			}
+		}
+
+		if c.buffer == nil {
+			c.buffer = make([]byte, copyBufferSize)
+		}
+		read, err := in.ReadAt(c.buffer[:min(n, copyBufferSize)], start)
+		if read > 0 {
+			if _, err := out.WriteAt(c.buffer[:read], start); err != nil {
+				return err
+			}
+			start += int64(read)
+			c.addBytes(in.Name(), int64(read))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyMetadata applies the extended attributes, the owner, the mode and
+// the timestamps of src to dst. The owner is kept only when permitted.
+func (c *copier) copyMetadata(src, dst string, info os.FileInfo) error {
+	isLink := info.Mode()&os.ModeSymlink != 0
+
+	attrs, err := readXattrs(src, c.options.FollowSymlinks)
+	if err != nil {
+		return err
+	}
+	if err := writeXattrs(dst, attrs, false); err != nil {
+		return err
+	}
+
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		if isLink {
+			return nil
+		}
+		return os.Chmod(dst, info.Mode())
+	}
+	if err := os.Lchown(dst, int(st.Uid), int(st.Gid)); err != nil && !errors.Is(err, fs.ErrPermission) {
+		return err
+	}
+	// the mode is set after the owner, as chown clears the setuid bit
+	if !isLink {
+		if err := os.Chmod(dst, info.Mode()); err != nil {
+			return err
+		}
+	}
+
+	times := []unix.Timespec{
+		{Sec: st.Atim.Sec, Nsec: st.Atim.Nsec},
+		{Sec: st.Mtim.Sec, Nsec: st.Mtim.Nsec},
+	}
+	if err := unix.UtimesNanoAt(unix.AT_FDCWD, dst, times, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+		return &os.PathError{Op: "utimensat", Path: dst, Err: err}
+	}
+	return nil
+}
+
+// addBytes reports the progress of the copy of a file.
+func (c *copier) addBytes(src string, n int64) {
+	if c.options.Progress == nil || n <= 0 {
+		return
+	}
+	c.progress.Path = src
+	c.progress.BytesCopied += n
+	c.options.Progress(c.progress)
+}
+
+// fileDone reports the end of the copy of a file.
+func (c *copier) fileDone(src string) {
+	if c.options.Progress == nil {
+		return
+	}
+	c.progress.Path = src
+	c.progress.FilesCopied++
+	c.options.Progress(c.progress)
+}
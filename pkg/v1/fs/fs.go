@@ -9,18 +9,25 @@ package fs
 */
 
 import (
-	"io/fs"
+	"context"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+	"github.com/vanilla-os/sdk/pkg/v1/system"
+	"golang.org/x/sys/unix"
 )
 
 // GetFileList returns a list of files in the specified directory.
 // If recursive is true, the function will recursively search for files, if
 // fullPaths is true, the full path of the file will be returned instead of
-// the relative path.
+// the relative path. Symlinks are described themselves, not their targets.
 //
 // Example:
 //
@@ -37,44 +44,42 @@ import (
 //		fmt.Printf("Extension: %s\n", file.Extension)
 //	}
 func GetFileList(directory string, recursive, fullPaths bool) ([]types.FileInfo, error) {
-	var fileList []types.FileInfo
+	return GetFileListWithOptions(directory, recursive, fullPaths, types.FileInfoOptions{})
+}
 
-	walkFunc := func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if !recursive && path != directory {
-			return fs.SkipDir
-		}
-		if d.IsDir() {
-			return nil
-		}
+// GetFileListWithOptions works like GetFileList, also reading the extended
+// attributes or the hashes of the files if requested by the options.
+//
+// Example:
+//
+//	fileList, err := fs.GetFileListWithOptions("/batmans/cave", true, false, types.FileInfoOptions{
+//		Hash: true,
+//	})
+//	if err != nil {
+//		fmt.Printf("Error: %v\n", err)
+//		return
+//	}
+//
+//	for _, file := range fileList {
+//		fmt.Printf("%s  %s\n", file.Hash, file.Path)
+//	}
+func GetFileListWithOptions(directory string, recursive, fullPaths bool, options types.FileInfoOptions) ([]types.FileInfo, error) {
+	walkOptions := types.WalkOptions{
+		NoDirectories: true,
+		RelativePaths: !fullPaths,
+		Parallel:      runtime.GOMAXPROCS(0),
+		Info:          options,
+	}
+	if !recursive {
+		walkOptions.MaxDepth = 1
+	}
 
-		info, err := d.Info()
+	var fileList []types.FileInfo
+	for file, err := range Walk(context.Background(), directory, walkOptions) {
 		if err != nil {
-			return err
-		}
-
-		filePath := path
-		if !fullPaths {
-			filePath, _ = filepath.Rel(directory, path)
+			return nil, err
 		}
-
-		permissions := convertPermissions(info.Mode())
-
-		fileList = append(fileList, types.FileInfo{
-			Path:        filePath,
-			ParentPath:  filepath.Dir(filePath),
-			IsDirectory: false,
-			Size:        info.Size(),
-			Permissions: permissions,
-			Extension:   GetFileExtension(path),
-		})
-		return nil
-	}
-
-	if err := filepath.WalkDir(directory, walkFunc); err != nil {
-		return nil, err
+		fileList = append(fileList, file)
 	}
 
 	return fileList, nil
@@ -97,7 +102,8 @@ func convertPermissions(mode os.FileMode) types.Permission {
 
 // GetFile returns the file info of the specified file.
 // If fullPath is true, the full path of the file will be returned instead of
-// the relative path.
+// the relative path. Symlinks are followed, IsSymlink and LinkTarget tell
+// whether the path itself is a symlink.
 //
 // Example:
 //
@@ -110,29 +116,170 @@ func convertPermissions(mode os.FileMode) types.Permission {
 //	fmt.Printf("Path: %s\n", file.Path)
 //	fmt.Printf("Size: %d\n", file.Size)
 //	fmt.Printf("Permissions: %s\n", file.Permissions.String())
+//	fmt.Printf("Owner: %s\n", file.Owner)
+//	fmt.Printf("Modified: %s\n", file.ModTime)
 func GetFile(filePath string, fullPath bool) (types.FileInfo, error) {
+	return GetFileWithOptions(filePath, fullPath, types.FileInfoOptions{})
+}
+
+// GetFileWithOptions works like GetFile, also reading the extended
+// attributes or the hash of the file if requested by the options.
+//
+// Example:
+//
+//	file, err := fs.GetFileWithOptions("/batmans/cave/batmobile.txt", true, types.FileInfoOptions{
+//		Xattrs: true,
+//		Hash:   true,
+//	})
+//	if err != nil {
+//		fmt.Printf("Error: %v\n", err)
+//		return
+//	}
+//
+//	fmt.Printf("SHA-256: %s\n", file.Hash)
+//	for name, value := range file.Xattrs {
+//		fmt.Printf("%s=%s\n", name, value)
+//	}
+func GetFileWithOptions(filePath string, fullPath bool, options types.FileInfoOptions) (types.FileInfo, error) {
 	info, err := os.Stat(filePath)
 	if err != nil {
 		return types.FileInfo{}, err
 	}
 
-	permissions := convertPermissions(info.Mode())
+	file, err := newFileInfoReader(options).read(filePath, info, true)
+	if err != nil {
+		return types.FileInfo{}, err
+	}
+
+	if !fullPath {
+		file.Path, _ = filepath.Rel(".", filePath)
+	}
+
+	return file, nil
+}
+
+// fileInfoReader fills the FileInfo of the files, caching the names of
+// their owners. It is safe for concurrent use.
+type fileInfoReader struct {
+	options types.FileInfoOptions
+	mu      sync.Mutex
+	users   map[uint32]string
+	groups  map[uint32]string
+}
+
+// newFileInfoReader returns a fileInfoReader reading the information
+// requested by the options.
+func newFileInfoReader(options types.FileInfoOptions) *fileInfoReader {
+	return &fileInfoReader{
+		options: options,
+		users:   make(map[uint32]string),
+		groups:  make(map[uint32]string),
+	}
+}
+
+// read returns the information of a file, given its os.Stat result if
+// follow is true or its os.Lstat result otherwise.
+func (r *fileInfoReader) read(path string, info os.FileInfo, follow bool) (types.FileInfo, error) {
 	file := types.FileInfo{
-		Path:        filePath,
-		ParentPath:  filepath.Dir(filePath),
+		Path:        path,
+		ParentPath:  filepath.Dir(path),
 		IsDirectory: info.IsDir(),
 		Size:        info.Size(),
-		Permissions: permissions,
-		Extension:   GetFileExtension(filePath),
+		Permissions: convertPermissions(info.Mode()),
+		Extension:   GetFileExtension(path),
+		Mode:        info.Mode(),
+		IsSymlink:   info.Mode()&os.ModeSymlink != 0,
+		ModTime:     info.ModTime(),
 	}
 
-	if !fullPath {
-		file.Path, _ = filepath.Rel(".", filePath)
+	if follow {
+		if linkInfo, err := os.Lstat(path); err == nil {
+			file.IsSymlink = linkInfo.Mode()&os.ModeSymlink != 0
+		}
+	}
+	if file.IsSymlink {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return types.FileInfo{}, err
+		}
+		file.LinkTarget = target
+	}
+
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		file.UID = st.Uid
+		file.GID = st.Gid
+		file.Owner = r.userName(st.Uid)
+		file.Group = r.groupName(st.Gid)
+		file.AccessTime = time.Unix(st.Atim.Unix())
+		file.ChangeTime = time.Unix(st.Ctim.Unix())
+		file.Device = st.Dev
+		file.Inode = st.Ino
+		file.Links = st.Nlink
+	}
+	file.BirthTime = birthTime(path, follow)
+
+	if r.options.Xattrs {
+		xattrs, err := readXattrs(path, follow)
+		if err != nil {
+			return types.FileInfo{}, &os.PathError{Op: "listxattr", Path: path, Err: err}
+		}
+		file.Xattrs = xattrs
+	}
+	if r.options.Hash && info.Mode().IsRegular() {
+		hash, err := hashFile(path)
+		if err != nil {
+			return types.FileInfo{}, err
+		}
+		file.Hash = hash
 	}
 
 	return file, nil
 }
 
+// userName returns the name of a user, looked up through the system users.
+func (r *fileInfoReader) userName(uid uint32) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name, ok := r.users[uid]
+	if !ok {
+		id := strconv.FormatUint(uint64(uid), 10)
+		name = system.GetUsers(nil, []string{id}, true)[id].Username
+		r.users[uid] = name
+	}
+	return name
+}
+
+// groupName returns the name of a group, looked up through the system
+// groups.
+func (r *fileInfoReader) groupName(gid uint32) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name, ok := r.groups[gid]
+	if !ok {
+		id := strconv.FormatUint(uint64(gid), 10)
+		name = system.GetGroups(nil, []string{id}, true)[id].Name
+		r.groups[gid] = name
+	}
+	return name
+}
+
+// birthTime returns the creation time of a file, if the filesystem
+// records it.
+func birthTime(path string, follow bool) time.Time {
+	flags := unix.AT_STATX_SYNC_AS_STAT
+	if !follow {
+		flags |= unix.AT_SYMLINK_NOFOLLOW
+	}
+
+	var stx unix.Statx_t
+	if err := unix.Statx(unix.AT_FDCWD, path, flags, unix.STATX_BTIME, &stx); err != nil || stx.Mask&unix.STATX_BTIME == 0 {
+		return time.Time{}
+	}
+	return time.Unix(stx.Btime.Sec, int64(stx.Btime.Nsec))
+}
+
 // GetFileExtension returns the extension of the given file
 //
 // Example:
@@ -191,7 +338,10 @@ func GetFileSize(filePath string) int64 {
 	return info.Size()
 }
 
-// WriteFileContent writes content to the specified file
+// WriteFileContent writes content to the specified file, truncating it in
+// place as os.WriteFile does, so it also works on device nodes, /proc and
+// /sys files and bind mounts. Use AtomicWriteFile to replace regular files
+// without the risk of leaving them truncated after a crash.
 //
 // Example:
 //
@@ -22,44 +22,6 @@ import (
 //		   making it easier to debug and monitor the application by both the
 //		   developer and the system administrator.
 
-// CopyFile copies the contents of one file to another
-//
-// Example:
-//
-//	err := fs.CopyFile("/tmp/batman", "/tmp/robin")
-//	if err != nil {
-//		fmt.Printf("Error copying file: %v", err)
-//		return
-//	}
-func CopyFile(sourcePath, destinationPath string) error {
-	source, err := os.ReadFile(sourcePath)
-	if err != nil {
-		return err
-	}
-	err = os.WriteFile(destinationPath, source, 0644)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-// MoveFile moves a file from one location to another
-//
-// Example:
-//
-//	err := fs.MoveFile("/tmp/batman", "/tmp/robin")
-//	if err != nil {
-//		fmt.Printf("Error moving file: %v", err)
-//		return
-//	}
-func MoveFile(sourcePath, destinationPath string) error {
-	err := os.Rename(sourcePath, destinationPath)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 // DeleteFile deletes a file
 //
 // Example:
@@ -0,0 +1,301 @@
+package fs
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Line based diffs and unified patches.
+*/
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+)
+
+// DefaultDiffContext is the number of context lines around the changes
+// used by diff -u.
+const DefaultDiffContext = 3
+
+// binarySniffSize is the number of bytes checked for binary content, the
+// same used by git.
+const binarySniffSize = 8000
+
+// hunkHeaderRe parses the header of a unified diff hunk.
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// IsBinary reports whether data looks binary, as git and diff do: it holds
+// a NUL byte in its first 8000 bytes.
+//
+// Example:
+//
+//	if fs.IsBinary(data) {
+//		fmt.Println("Binary content not shown")
+//	}
+func IsBinary(data []byte) bool {
+	return bytes.IndexByte(data[:min(len(data), binarySniffSize)], 0) >= 0
+}
+
+// IsBinaryFile reports whether a file looks binary, reading only its first
+// bytes.
+//
+// Example:
+//
+//	binary, err := fs.IsBinaryFile("/usr/bin/batsignal")
+func IsBinaryFile(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, binarySniffSize)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, err
+	}
+	return IsBinary(buf[:n]), nil
+}
+
+// GetLineDiff compares two files line by line and returns the changed
+// lines grouped in hunks with the given number of context lines, usually
+// DefaultDiffContext. Binary files are only reported as such.
+//
+// Example:
+//
+//	diff, err := fs.GetLineDiff("/etc/hosts.orig", "/etc/hosts", fs.DefaultDiffContext)
+//	if err != nil {
+//		fmt.Printf("Error getting file diff: %v", err)
+//		return
+//	}
+//	fmt.Print(diff.Unified())
+func GetLineDiff(firstFile, secondFile string, context int) (types.LineDiff, error) {
+	firstContent, err := os.ReadFile(firstFile)
+	if err != nil {
+		return types.LineDiff{}, err
+	}
+	secondContent, err := os.ReadFile(secondFile)
+	if err != nil {
+		return types.LineDiff{}, err
+	}
+
+	if IsBinary(firstContent) || IsBinary(secondContent) {
+		return types.LineDiff{
+			OldName: firstFile,
+			NewName: secondFile,
+			Binary:  true,
+			Equal:   bytes.Equal(firstContent, secondContent),
+		}, nil
+	}
+	return DiffText(firstFile, secondFile, string(firstContent), string(secondContent), context), nil
+}
+
+// DiffText compares two texts line by line, see GetLineDiff. The names are
+// used in the headers of the unified format.
+//
+// Example:
+//
+//	diff := fs.DiffText("a/motd", "b/motd", "Welcome\n", "Welcome home\n", fs.DefaultDiffContext)
+//	fmt.Print(diff.Unified())
+func DiffText(oldName, newName, oldText, newText string, context int) types.LineDiff {
+	diff := types.LineDiff{OldName: oldName, NewName: newName, Equal: oldText == newText}
+	if !diff.Equal {
+		diff.Hunks = groupHunks(diffLines(oldText, newText), max(context, 0))
+	}
+	return diff
+}
+
+// lineOp is a line of a line diff, with the numbers of lines preceding it
+// in each text.
+type lineOp struct {
+	types.DiffLine
+	oldPos, newPos int
+}
+
+// diffLines returns the line diff between two texts.
+func diffLines(oldText, newText string) []lineOp {
+	dmp := diffmatchpatch.New()
+	oldRunes, newRunes, lines := dmp.DiffLinesToRunes(oldText, newText)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMainRunes(oldRunes, newRunes, false), lines)
+
+	var ops []lineOp
+	oldLine, newLine := 1, 1
+	for _, d := range diffs {
+		for _, line := range splitLines(d.Text) {
+			op := lineOp{DiffLine: types.DiffLine{Text: line}, oldPos: oldLine - 1, newPos: newLine - 1}
+			switch d.Type {
+			case diffmatchpatch.DiffEqual:
+				op.Kind, op.OldLine, op.NewLine = types.DiffContext, oldLine, newLine
+				oldLine++
+				newLine++
+			case diffmatchpatch.DiffDelete:
+				op.Kind, op.OldLine = types.DiffRemoved, oldLine
+				oldLine++
+			case diffmatchpatch.DiffInsert:
+				op.Kind, op.NewLine = types.DiffAdded, newLine
+				newLine++
+			}
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// splitLines splits a text after each newline, the last line may miss it.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// groupHunks groups the changes of a line diff into hunks with the given
+// context lines, merging the hunks whose context overlaps.
+func groupHunks(ops []lineOp, context int) []types.DiffHunk {
+	var hunks []types.DiffHunk
+	for i := 0; i < len(ops); {
+		if ops[i].Kind == types.DiffContext {
+			i++
+			continue
+		}
+
+		start := max(0, i-context)
+		end := i
+		for end < len(ops) {
+			if ops[end].Kind != types.DiffContext {
+				end++
+				continue
+			}
+			// the hunk ends if the next change is too far away
+			next := end
+			for next < len(ops) && ops[next].Kind == types.DiffContext {
+				next++
+			}
+			if next == len(ops) || next-end > 2*context {
+				end = min(len(ops), end+context)
+				break
+			}
+			end = next
+		}
+
+		hunks = append(hunks, newHunk(ops[start:end]))
+		i = end
+	}
+	return hunks
+}
+
+// newHunk returns the hunk of a range of a line diff, numbered as diff -u
+// does: an empty side starts at the line preceding the hunk.
+func newHunk(ops []lineOp) types.DiffHunk {
+	h := types.DiffHunk{OldStart: ops[0].oldPos, NewStart: ops[0].newPos}
+	for _, op := range ops {
+		if op.Kind != types.DiffAdded {
+			h.OldLines++
+		}
+		if op.Kind != types.DiffRemoved {
+			h.NewLines++
+		}
+		h.Lines = append(h.Lines, op.DiffLine)
+	}
+	if h.OldLines > 0 {
+		h.OldStart++
+	}
+	if h.NewLines > 0 {
+		h.NewStart++
+	}
+	return h
+}
+
+// applyUnified applies a unified diff to a text. Each hunk is searched
+// from its expected position onward, so that the diff applies to a text
+// changed elsewhere, and must match exactly.
+func applyUnified(text, diff string) (string, error) {
+	lines := splitLines(text)
+	var result []string
+	cursor := 0
+
+	patchLines := splitLines(diff)
+	for i := 0; i < len(patchLines); {
+		match := hunkHeaderRe.FindStringSubmatch(patchLines[i])
+		if match == nil {
+			i++ // file headers and garbage before the first hunk
+			continue
+		}
+		i++
+
+		// collect the old and the new lines of the hunk
+		var oldLines, newLines []string
+		var last byte
+		for i < len(patchLines) && !strings.HasPrefix(patchLines[i], "@@") {
+			line := patchLines[i]
+			i++
+			if strings.HasPrefix(line, types.NoNewlineMarker) {
+				// the previous line misses the final newline
+				if last != '+' && len(oldLines) > 0 {
+					oldLines[len(oldLines)-1] = strings.TrimSuffix(oldLines[len(oldLines)-1], "\n")
+				}
+				if last != '-' && len(newLines) > 0 {
+					newLines[len(newLines)-1] = strings.TrimSuffix(newLines[len(newLines)-1], "\n")
+				}
+				continue
+			}
+			if line == "" {
+				continue
+			}
+			last = line[0]
+			switch last {
+			case ' ':
+				oldLines = append(oldLines, line[1:])
+				newLines = append(newLines, line[1:])
+			case '-':
+				oldLines = append(oldLines, line[1:])
+			case '+':
+				newLines = append(newLines, line[1:])
+			}
+		}
+
+		start, _ := strconv.Atoi(match[1])
+		if len(oldLines) > 0 {
+			start--
+		}
+		at := findLines(lines, oldLines, max(cursor, start))
+		if at < 0 {
+			at = findLines(lines, oldLines, cursor)
+		}
+		if at < 0 {
+			return "", fmt.Errorf("hunk at line %s does not apply", match[1])
+		}
+		result = append(result, lines[cursor:at]...)
+		result = append(result, newLines...)
+		cursor = at + len(oldLines)
+	}
+	result = append(result, lines[cursor:]...)
+	return strings.Join(result, ""), nil
+}
+
+// findLines returns the index of the first occurrence of want in lines
+// starting from start, or -1.
+func findLines(lines, want []string, start int) int {
+	for i := start; i+len(want) <= len(lines); i++ {
+		match := true
+		for j := range want {
+			if lines[i+j] != want[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,166 @@
+package fs
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Moving of files and trees across filesystems.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+	"golang.org/x/sys/unix"
+)
+
+// MoveFile moves a file or a directory tree from one location to another,
+// replacing the destination if it is a file or an empty directory. When
+// the source and the destination are on different filesystems, e.g. /home
+// and /var, it falls back to copying, see MoveFileWithOptions.
+//
+// Example:
+//
+//	err := fs.MoveFile("/tmp/batman", "/tmp/robin")
+//	if err != nil {
+//		fmt.Printf("Error moving file: %v", err)
+//		return
+//	}
+func MoveFile(sourcePath, destinationPath string) error {
+	return MoveFileWithOptions(context.Background(), sourcePath, destinationPath, types.MoveOptions{})
+}
+
+// MoveFileWithOptions moves a file or a directory tree as MoveFile does,
+// with options and a context to cancel the move. If the source cannot be
+// renamed because the destination is on another filesystem, it is copied,
+// preserving its metadata as CopyTree does, next to the destination under
+// a temporary name, synced to disk and then renamed over the destination,
+// so that the destination is either untouched or complete. The source is
+// removed only after that. If the copy fails or is cancelled, the partial
+// copy is removed and the source is left as it is.
+//
+// Example:
+//
+//	err := fs.MoveFileWithOptions(ctx, "/home/bruce/cave", "/var/lib/cave", types.MoveOptions{
+//		NoOverwrite: true,
+//	})
+//	if errors.Is(err, fs.ErrExist) {
+//		fmt.Println("The cave was already moved")
+//	}
+func MoveFileWithOptions(ctx context.Context, sourcePath, destinationPath string, options types.MoveOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := rename(sourcePath, destinationPath, options.NoOverwrite)
+	if !errors.Is(err, unix.EXDEV) {
+		return err
+	}
+	return moveAcross(ctx, sourcePath, destinationPath, options)
+}
+
+// rename renames a file, failing with fs.ErrExist if the destination
+// exists and noOverwrite is true.
+func rename(sourcePath, destinationPath string, noOverwrite bool) error {
+	if !noOverwrite {
+		return os.Rename(sourcePath, destinationPath)
+	}
+
+	err := unix.Renameat2(unix.AT_FDCWD, sourcePath, unix.AT_FDCWD, destinationPath, unix.RENAME_NOREPLACE)
+	if errors.Is(err, unix.EINVAL) || errors.Is(err, unix.ENOSYS) {
+		// the filesystem does not support RENAME_NOREPLACE
+		if _, err := os.Lstat(destinationPath); err == nil {
+			return &os.LinkError{Op: "rename", Old: sourcePath, New: destinationPath, Err: fs.ErrExist}
+		}
+		return os.Rename(sourcePath, destinationPath)
+	}
+	if err != nil {
+		return &os.LinkError{Op: "rename", Old: sourcePath, New: destinationPath, Err: err}
+	}
+	return nil
+}
+
+// moveAcross moves a file or a tree to another filesystem by copying it.
+func moveAcross(ctx context.Context, sourcePath, destinationPath string, options types.MoveOptions) error {
+	// the source must be removable before anything is copied, so that a
+	// move is never left with both copies because of the permissions
+	sourceParent := filepath.Dir(sourcePath)
+	if err := unix.Access(sourceParent, unix.W_OK|unix.X_OK); err != nil {
+		return &os.PathError{Op: "move", Path: sourcePath, Err: err}
+	}
+	if options.NoOverwrite {
+		if _, err := os.Lstat(destinationPath); err == nil {
+			return &os.LinkError{Op: "move", Old: sourcePath, New: destinationPath, Err: fs.ErrExist}
+		}
+	}
+
+	// the copy is staged next to the destination, on its filesystem
+	destinationParent := filepath.Dir(destinationPath)
+	staging, err := os.MkdirTemp(destinationParent, "."+filepath.Base(destinationPath)+".move-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	staged := filepath.Join(staging, filepath.Base(destinationPath))
+	err = CopyTree(ctx, sourcePath, staged, types.CopyOptions{Progress: options.Progress})
+	if err != nil {
+		return fmt.Errorf("cannot move %s to %s: %w", sourcePath, destinationPath, err)
+	}
+	if err := syncTree(staged); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := rename(staged, destinationPath, options.NoOverwrite); err != nil {
+		return err
+	}
+	if err := syncDir(destinationParent); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(sourcePath); err != nil {
+		return fmt.Errorf("%s moved to %s, but the source could not be removed: %w", sourcePath, destinationPath, err)
+	}
+	return syncDir(sourceParent)
+}
+
+// syncTree flushes to disk a file or, recursively, a directory and its
+// content.
+func syncTree(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil
+		}
+		return syncPath(path)
+	})
+}
+
+// syncDir flushes to disk the entries of a directory, making the renames
+// and removals in it durable.
+func syncDir(path string) error {
+	return syncPath(path)
+}
+
+// syncPath flushes a file or a directory to disk.
+func syncPath(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := f.Sync(); err != nil && !errors.Is(err, unix.EINVAL) {
+		return err
+	}
+	return nil
+}
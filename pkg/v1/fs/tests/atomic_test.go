@@ -0,0 +1,125 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"errors"
+	"io"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs"
+	"golang.org/x/sys/unix"
+)
+
+func TestAtomicWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "batcave.conf")
+
+	if err := fs.AtomicWriteFile(path, []byte("signal = off\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("expected a new file with mode 0600, got %v", info)
+	}
+
+	if err := os.Chmod(path, 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("batcave.conf", filepath.Join(dir, "link.conf")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.AtomicWriteFile(filepath.Join(dir, "link.conf"), []byte("signal = on\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := os.ReadFile(path); err != nil || string(data) != "signal = on\n" {
+		t.Errorf("unexpected content %q: %v", data, err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0640 {
+		t.Errorf("expected the mode to be preserved, got %v", info)
+	}
+	if info, err := os.Lstat(filepath.Join(dir, "link.conf")); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("expected the symlink to be preserved, got %v", info)
+	}
+	assertNoTempFiles(t, dir, 2)
+}
+
+func TestAtomicWriteFileUmask(t *testing.T) {
+	old := unix.Umask(027)
+	defer unix.Umask(old)
+
+	path := filepath.Join(t.TempDir(), "batcave.conf")
+	if err := fs.AtomicWriteFile(path, []byte("signal = off\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0640 {
+		t.Errorf("expected the umask to be applied, got %v: %v", info, err)
+	}
+}
+
+func TestAtomicWriter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "batcave.conf")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := fs.NewAtomicWriter(path, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.Copy(w, strings.NewReader("partial")); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("expected the file to be untouched before Close, got %q", data)
+	}
+	if err := w.Abort(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("expected the file to be untouched after Abort, got %q", data)
+	}
+
+	w, err = fs.NewAtomicWriter(path, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Abort()
+	if _, err := io.WriteString(w, "new"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("late")); !errors.Is(err, iofs.ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new" {
+		t.Errorf("unexpected content %q", data)
+	}
+	assertNoTempFiles(t, dir, 1)
+}
+
+// assertNoTempFiles checks that a directory holds only the expected number
+// of entries, i.e. no temporary file was left behind.
+func assertNoTempFiles(t *testing.T, dir string, expected int) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != expected {
+		t.Errorf("expected %d entries, got %d", expected, len(entries))
+	}
+}
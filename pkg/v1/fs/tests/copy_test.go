@@ -0,0 +1,296 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs"
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+	"golang.org/x/sys/unix"
+)
+
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "batman")
+	dst := filepath.Join(dir, "robin")
+
+	if err := os.WriteFile(src, []byte("I am Batman!"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := os.Chtimes(src, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	hasXattrs := unix.Setxattr(src, "user.hero", []byte("yes"), 0) == nil
+
+	if err := fs.CopyFile(src, dst); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(dst)
+	if err != nil || string(data) != "I am Batman!" {
+		t.Fatalf("unexpected content %q: %v", data, err)
+	}
+	info, err := os.Stat(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("expected mode 0640, got %v", info.Mode().Perm())
+	}
+	if !info.ModTime().Equal(mtime) {
+		t.Errorf("expected mtime %v, got %v", mtime, info.ModTime())
+	}
+	if hasXattrs {
+		value := make([]byte, 16)
+		n, err := unix.Getxattr(dst, "user.hero", value)
+		if err != nil || string(value[:n]) != "yes" {
+			t.Errorf("extended attribute not copied: %v", err)
+		}
+	}
+
+	err = fs.CopyFileWithOptions(context.Background(), src, dst, types.CopyOptions{NoOverwrite: true})
+	if !errors.Is(err, iofs.ErrExist) {
+		t.Errorf("expected ErrExist, got %v", err)
+	}
+}
+
+func TestCopyFileSparse(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "sparse")
+	dst := filepath.Join(dir, "sparse-copy")
+
+	f, err := os.Create(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte("start"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteAt([]byte("end"), 64<<20); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Truncate(128 << 20); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	var progress types.CopyProgress
+	err = fs.CopyFileWithOptions(context.Background(), src, dst, types.CopyOptions{
+		Progress: func(p types.CopyProgress) { progress = p },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress.BytesCopied != 128<<20 || progress.BytesTotal != 128<<20 || progress.FilesCopied != 1 {
+		t.Errorf("unexpected final progress %+v", progress)
+	}
+
+	var srcStat, dstStat syscall.Stat_t
+	if err := syscall.Stat(src, &srcStat); err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Stat(dst, &dstStat); err != nil {
+		t.Fatal(err)
+	}
+	if dstStat.Size != srcStat.Size {
+		t.Errorf("expected size %d, got %d", srcStat.Size, dstStat.Size)
+	}
+	if srcStat.Blocks*512 < srcStat.Size && dstStat.Blocks*512 >= dstStat.Size {
+		t.Errorf("holes not preserved: %d blocks", dstStat.Blocks)
+	}
+
+	data := make([]byte, 3)
+	copied, err := os.Open(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer copied.Close()
+	if _, err := copied.ReadAt(data, 64<<20); err != nil || string(data) != "end" {
+		t.Errorf("unexpected data after the hole %q: %v", data, err)
+	}
+}
+
+func TestCopyFileSpecial(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "batcave"), []byte("bats"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("batcave", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	// CopyFile follows symlinks, the options can preserve them
+	if err := fs.CopyFile(filepath.Join(dir, "link"), filepath.Join(dir, "link-copy")); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Lstat(filepath.Join(dir, "link-copy")); err != nil || !info.Mode().IsRegular() {
+		t.Errorf("expected a regular file, got %v: %v", info, err)
+	}
+	err := fs.CopyFileWithOptions(context.Background(), filepath.Join(dir, "link"), filepath.Join(dir, "link-kept"), types.CopyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target, err := os.Readlink(filepath.Join(dir, "link-kept")); err != nil || target != "batcave" {
+		t.Errorf("expected a symlink to batcave, got %q: %v", target, err)
+	}
+
+	if err := unix.Mkfifo(filepath.Join(dir, "fifo"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.CopyFile(filepath.Join(dir, "fifo"), filepath.Join(dir, "fifo-copy")); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Lstat(filepath.Join(dir, "fifo-copy")); err != nil || info.Mode()&os.ModeNamedPipe == 0 {
+		t.Errorf("expected a FIFO, got %v: %v", info, err)
+	}
+}
+
+func TestCopyTree(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "cave")
+	dst := filepath.Join(dir, "backup")
+
+	for name, content := range map[string]string{
+		"gadgets/belt.conf":   "belt",
+		"gadgets/rope.conf":   "rope",
+		"gadgets/notes.swp":   "swap",
+		"cache/garbage.conf":  "garbage",
+		"vehicles/batmobile":  "vroom",
+		"vehicles/batwing.db": "fly",
+	} {
+		path := filepath.Join(src, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Link(filepath.Join(src, "gadgets/belt.conf"), filepath.Join(src, "belt.conf")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(filepath.Join(src, "vehicles"), 0555); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(filepath.Join(src, "vehicles"), 0755)
+
+	var last types.CopyProgress
+	err := fs.CopyTree(context.Background(), src, dst, types.CopyOptions{
+		Include:  []string{"*.conf", "vehicles/*"},
+		Exclude:  []string{"*.db", "cache"},
+		Progress: func(p types.CopyProgress) { last = p },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(filepath.Join(dst, "vehicles"), 0755)
+
+	for name, expected := range map[string]bool{
+		"gadgets/belt.conf":   true,
+		"gadgets/rope.conf":   true,
+		"belt.conf":           true,
+		"vehicles/batmobile":  true,
+		"gadgets/notes.swp":   false,
+		"vehicles/batwing.db": false,
+		"cache":               false,
+	} {
+		_, err := os.Lstat(filepath.Join(dst, filepath.FromSlash(name)))
+		if exists := err == nil; exists != expected {
+			t.Errorf("expected %s to exist: %v", name, expected)
+		}
+	}
+
+	if last.FilesCopied != 4 || last.FilesTotal != 4 || last.BytesCopied != last.BytesTotal {
+		t.Errorf("unexpected final progress %+v", last)
+	}
+
+	first, err := os.Stat(filepath.Join(dst, "belt.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := os.Stat(filepath.Join(dst, "gadgets/belt.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(first, second) {
+		t.Error("expected hard links to be preserved")
+	}
+	if info, err := os.Stat(filepath.Join(dst, "vehicles")); err != nil || info.Mode().Perm() != 0555 {
+		t.Errorf("expected the directory mode to be preserved, got %v", info.Mode())
+	}
+
+	if err := fs.CopyTree(context.Background(), src, filepath.Join(src, "gadgets"), types.CopyOptions{}); err == nil {
+		t.Error("expected copying a tree into itself to fail")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = fs.CopyTree(ctx, src, filepath.Join(dir, "cancelled"), types.CopyOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestCopyFileKeepsDestination(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "batcave")
+	link := filepath.Join(dir, "link")
+	if err := os.WriteFile(file, []byte("bats"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("batcave", link); err != nil {
+		t.Fatal(err)
+	}
+
+	// copying a file to itself, directly or through a symlink, fails
+	if err := fs.CopyFile(file, file); err == nil {
+		t.Error("expected an error copying a file to itself")
+	}
+	if err := fs.CopyFile(link, file); err == nil {
+		t.Error("expected an error copying a symlink to its target")
+	}
+	if data, err := os.ReadFile(file); err != nil || string(data) != "bats" {
+		t.Errorf("the file changed: %q, %v", data, err)
+	}
+	if info, err := os.Lstat(file); err != nil || !info.Mode().IsRegular() {
+		t.Errorf("expected the file to stay a regular file, got %v: %v", info, err)
+	}
+
+	// a failed copy leaves the destination untouched
+	if err := fs.CopyFile(filepath.Join(dir, "missing"), file); err == nil {
+		t.Error("expected an error copying a missing file")
+	}
+	large := filepath.Join(dir, "large")
+	if err := os.WriteFile(large, bytes.Repeat([]byte("x"), 17<<20), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := fs.CopyFileWithOptions(ctx, large, file, types.CopyOptions{
+		Progress: func(types.CopyProgress) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if data, err := os.ReadFile(file); err != nil || string(data) != "bats" {
+		t.Errorf("the destination changed: %q, %v", data, err)
+	}
+	assertNoTempFiles(t, dir, 3)
+}
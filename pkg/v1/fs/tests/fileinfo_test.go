@@ -0,0 +1,129 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"errors"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs"
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+	"golang.org/x/sys/unix"
+)
+
+func TestGetFileInfo(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "batmobile.txt")
+	if err := os.WriteFile(file, []byte("hello"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(file, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(file, filepath.Join(dir, "hardlink")); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink("batmobile.txt", link); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := fs.GetFile(file, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.IsSymlink || info.LinkTarget != "" {
+		t.Errorf("unexpected symlink info: %+v", info)
+	}
+	if info.Mode != 0640 || !info.ModTime.Equal(modTime) {
+		t.Errorf("unexpected mode or modification time: %v, %v", info.Mode, info.ModTime)
+	}
+	if info.Links != 2 || info.Inode == 0 {
+		t.Errorf("expected 2 links and an inode, got %d and %d", info.Links, info.Inode)
+	}
+	if info.UID != uint32(os.Getuid()) || info.GID != uint32(os.Getgid()) {
+		t.Errorf("unexpected owner %d:%d", info.UID, info.GID)
+	}
+	if u, err := user.LookupId(strconv.Itoa(os.Getuid())); err == nil && info.Owner != u.Username {
+		t.Errorf("expected owner %q, got %q", u.Username, info.Owner)
+	}
+	if info.Xattrs != nil || info.Hash != "" {
+		t.Errorf("expected no xattrs nor hash unless requested, got %+v", info)
+	}
+
+	// GetFile follows symlinks but reports them
+	linkInfo, err := fs.GetFile(link, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !linkInfo.IsSymlink || linkInfo.LinkTarget != "batmobile.txt" || linkInfo.Inode != info.Inode {
+		t.Errorf("unexpected info for the symlink: %+v", linkInfo)
+	}
+}
+
+func TestGetFileInfoOptions(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "cave")
+	if err := os.WriteFile(file, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	xattrs := true
+	if err := unix.Setxattr(file, "user.owner", []byte("bruce"), 0); err != nil {
+		if !errors.Is(err, unix.ENOTSUP) {
+			t.Fatal(err)
+		}
+		xattrs = false
+	}
+
+	info, err := fs.GetFileWithOptions(file, true, types.FileInfoOptions{Xattrs: true, Hash: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Hash != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Errorf("unexpected hash %q", info.Hash)
+	}
+	if xattrs && string(info.Xattrs["user.owner"]) != "bruce" {
+		t.Errorf("unexpected xattrs %v", info.Xattrs)
+	}
+}
+
+func TestGetFileListInfo(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("file", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := fs.GetFileListWithOptions(dir, true, false, types.FileInfoOptions{Hash: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %+v", files)
+	}
+
+	byPath := map[string]types.FileInfo{}
+	for _, file := range files {
+		byPath[file.Path] = file
+	}
+	if link := byPath["link"]; !link.IsSymlink || link.LinkTarget != "file" || link.Hash != "" {
+		t.Errorf("expected the symlink itself to be described, got %+v", link)
+	}
+	if file := byPath["file"]; file.IsSymlink || file.Hash == "" || file.ModTime.IsZero() {
+		t.Errorf("unexpected info for the regular file: %+v", file)
+	}
+}
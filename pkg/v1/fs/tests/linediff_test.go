@@ -0,0 +1,105 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs"
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+)
+
+func TestDiffTextUnified(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 1; i <= 20; i++ {
+		line := "line " + strings.Repeat("x", i%3)
+		oldLines = append(oldLines, line)
+		newLines = append(newLines, line)
+	}
+	newLines[1] = "changed"
+	newLines = append(newLines[:15], append([]string{"inserted"}, newLines[15:]...)...)
+
+	oldText := strings.Join(oldLines, "\n") + "\n"
+	newText := strings.Join(newLines, "\n") + "\n"
+
+	diff := fs.DiffText("a/file", "b/file", oldText, newText, fs.DefaultDiffContext)
+	if diff.Equal || diff.Binary {
+		t.Fatalf("unexpected diff flags: %+v", diff)
+	}
+	if len(diff.Hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %d", len(diff.Hunks))
+	}
+	if diff.Added() != 2 || diff.Removed() != 1 {
+		t.Errorf("expected +2 -1, got +%d -%d", diff.Added(), diff.Removed())
+	}
+
+	expected := "--- a/file\n+++ b/file\n" +
+		"@@ -1,5 +1,5 @@\n line x\n-line xx\n+changed\n line \n line x\n line xx\n" +
+		"@@ -13,6 +13,7 @@\n line x\n line xx\n line \n+inserted\n line x\n line xx\n line \n"
+	if got := diff.Unified(); got != expected {
+		t.Errorf("unexpected unified diff:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	added := diff.Hunks[1].Lines[3]
+	if added.Kind != types.DiffAdded || added.OldLine != 0 || added.NewLine != 16 {
+		t.Errorf("unexpected line numbers for the inserted line: %+v", added)
+	}
+	context := diff.Hunks[1].Lines[4]
+	if context.OldLine != 16 || context.NewLine != 17 {
+		t.Errorf("unexpected line numbers for a context line: %+v", context)
+	}
+}
+
+func TestDiffTextNoNewline(t *testing.T) {
+	diff := fs.DiffText("a", "b", "one\ntwo", "one\ntwo\n", 1)
+
+	expected := "--- a\n+++ b\n@@ -1,2 +1,2 @@\n one\n-two\n" + types.NoNewlineMarker + "\n+two\n"
+	if got := diff.Unified(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	if same := fs.DiffText("a", "b", "one\n", "one\n", 3); !same.Equal || same.Unified() != "" {
+		t.Errorf("expected no differences, got %+v", same)
+	}
+}
+
+func TestGetLineDiffBinary(t *testing.T) {
+	dir := t.TempDir()
+	textFile := filepath.Join(dir, "text")
+	binaryFile := filepath.Join(dir, "binary")
+
+	if err := os.WriteFile(textFile, []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(binaryFile, []byte("hello\x00world"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	binary, err := fs.IsBinaryFile(binaryFile)
+	if err != nil || !binary {
+		t.Fatalf("expected a binary file, got %v, %v", binary, err)
+	}
+	if binary, _ := fs.IsBinaryFile(textFile); binary {
+		t.Error("expected a text file")
+	}
+
+	diff, err := fs.GetLineDiff(textFile, binaryFile, fs.DefaultDiffContext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Binary || len(diff.Hunks) != 0 {
+		t.Fatalf("expected a binary diff without hunks, got %+v", diff)
+	}
+	if got := diff.Unified(); got != "Binary files "+textFile+" and "+binaryFile+" differ\n" {
+		t.Errorf("unexpected binary diff output: %q", got)
+	}
+}
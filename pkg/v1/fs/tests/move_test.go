@@ -0,0 +1,134 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"context"
+	"errors"
+	iofs "io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs"
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+)
+
+// crossDeviceDirs returns two temporary directories on different
+// filesystems, skipping the test if none is available.
+func crossDeviceDirs(t *testing.T) (string, string) {
+	t.Helper()
+	first := t.TempDir()
+
+	second, err := os.MkdirTemp("/dev/shm", "sdk-move-")
+	if err != nil {
+		t.Skip("no second filesystem available")
+	}
+	t.Cleanup(func() { os.RemoveAll(second) })
+
+	var a, b syscall.Stat_t
+	if syscall.Stat(first, &a) != nil || syscall.Stat(second, &b) != nil || a.Dev == b.Dev {
+		t.Skip("no second filesystem available")
+	}
+	return first, second
+}
+
+func TestMoveFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "batman")
+	dst := filepath.Join(dir, "robin")
+
+	if err := os.WriteFile(src, []byte("I am Batman!"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, []byte("I am Robin!"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := fs.MoveFileWithOptions(context.Background(), src, dst, types.MoveOptions{NoOverwrite: true})
+	if !errors.Is(err, iofs.ErrExist) {
+		t.Errorf("expected ErrExist, got %v", err)
+	}
+	if err := fs.MoveFile(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(dst); err != nil || string(data) != "I am Batman!" {
+		t.Errorf("unexpected content %q: %v", data, err)
+	}
+	if _, err := os.Stat(src); !errors.Is(err, iofs.ErrNotExist) {
+		t.Errorf("expected the source to be removed, got %v", err)
+	}
+}
+
+func TestMoveFileCrossDevice(t *testing.T) {
+	first, second := crossDeviceDirs(t)
+
+	src := filepath.Join(first, "cave")
+	if err := os.MkdirAll(filepath.Join(src, "gadgets"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "gadgets", "belt"), []byte("belt"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("gadgets/belt", filepath.Join(src, "belt")); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(second, "cave")
+	if err := fs.MoveFile(src, dst); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(src); !errors.Is(err, iofs.ErrNotExist) {
+		t.Errorf("expected the source to be removed, got %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dst, "belt")); err != nil || string(data) != "belt" {
+		t.Errorf("unexpected content %q: %v", data, err)
+	}
+	if info, err := os.Stat(filepath.Join(dst, "gadgets")); err != nil || info.Mode().Perm() != 0750 {
+		t.Errorf("expected the mode to be preserved, got %v", info)
+	}
+
+	entries, err := os.ReadDir(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected no staging directory left, got %d entries", len(entries))
+	}
+}
+
+func TestMoveFileCrossDeviceCancelled(t *testing.T) {
+	first, second := crossDeviceDirs(t)
+
+	src := filepath.Join(first, "batman")
+	if err := os.WriteFile(src, make([]byte, 1<<20), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := fs.MoveFileWithOptions(ctx, src, filepath.Join(second, "batman"), types.MoveOptions{
+		Progress: func(types.CopyProgress) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if _, err := os.Stat(src); err != nil {
+		t.Errorf("expected the source to be kept, got %v", err)
+	}
+	entries, err := os.ReadDir(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected nothing left at the destination, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,185 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs"
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+)
+
+// writeTree creates the files of a tree, directories are created as needed.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDiffTrees(t *testing.T) {
+	dir := t.TempDir()
+	oldRoot := filepath.Join(dir, "old")
+	newRoot := filepath.Join(dir, "new")
+
+	hosts := "127.0.0.1 localhost\n::1 localhost\n# batcave\n10.0.0.1 cave\n"
+	writeTree(t, oldRoot, map[string]string{
+		"etc/hosts":      hosts,
+		"etc/motd":       "Welcome\n",
+		"etc/old.conf":   "obsolete\n",
+		"usr/bin/signal": "\x00\x01binary",
+		"var/cache/tmp":  "ignored",
+	})
+	writeTree(t, newRoot, map[string]string{
+		"etc/hosts":      strings.Replace(hosts, "10.0.0.1", "10.0.0.2", 1),
+		"etc/motd":       "Welcome\n",
+		"etc/new.conf":   "fresh\n",
+		"usr/bin/signal": "\x00\x02binary",
+		"var/cache/tmp":  "changed but ignored",
+	})
+	if err := os.Chmod(filepath.Join(newRoot, "etc/motd"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("hosts", filepath.Join(newRoot, "etc/hosts.link")); err != nil {
+		t.Fatal(err)
+	}
+
+	changeset, err := fs.DiffTrees(context.Background(), oldRoot, newRoot, types.TreeDiffOptions{
+		Exclude: []string{"var/cache"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]types.TreeChange{}
+	for _, change := range changeset.Changes {
+		got[change.Path] = change
+	}
+	for p, kind := range map[string]types.ChangeKind{
+		"etc/hosts":      types.ChangeModified,
+		"etc/hosts.link": types.ChangeAdded,
+		"etc/motd":       types.ChangeModified,
+		"etc/new.conf":   types.ChangeAdded,
+		"etc/old.conf":   types.ChangeRemoved,
+		"usr/bin/signal": types.ChangeModified,
+	} {
+		if got[p].Kind != kind {
+			t.Errorf("expected %s to be %s, got %q", p, kind, got[p].Kind)
+		}
+	}
+	if len(changeset.Changes) != 6 {
+		t.Errorf("expected 6 changes, got %d", len(changeset.Changes))
+	}
+
+	expectedDiff := "--- a/etc/hosts\n+++ b/etc/hosts\n@@ -1,4 +1,4 @@\n 127.0.0.1 localhost\n ::1 localhost\n # batcave\n-10.0.0.1 cave\n+10.0.0.2 cave\n"
+	if diff := got["etc/hosts"].Diff; diff != expectedDiff {
+		t.Errorf("unexpected diff:\n%s", diff)
+	}
+	if fields := got["etc/motd"].Fields; len(fields) != 1 || fields[0] != types.FieldMode {
+		t.Errorf("expected only the mode of motd to change, got %v", fields)
+	}
+	if !got["usr/bin/signal"].Binary || got["usr/bin/signal"].Content == nil {
+		t.Error("expected the binary file content in the changeset")
+	}
+
+	// the changeset survives serialization and applies to a third tree
+	// changed elsewhere
+	var buf bytes.Buffer
+	if err := fs.WriteChangeset(&buf, changeset); err != nil {
+		t.Fatal(err)
+	}
+	changeset, err = fs.ReadChangeset(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	third := filepath.Join(dir, "third")
+	if err := fs.CopyTree(context.Background(), oldRoot, third, types.CopyOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	thirdHosts := filepath.Join(third, "etc/hosts")
+	if err := os.WriteFile(thirdHosts, []byte("# managed\n"+hosts), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.ApplyChangeset(context.Background(), third, changeset); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(thirdHosts)
+	if err != nil || string(data) != "# managed\n"+strings.Replace(hosts, "10.0.0.1", "10.0.0.2", 1) {
+		t.Errorf("unexpected patched hosts %q: %v", data, err)
+	}
+	if err := os.WriteFile(thirdHosts, []byte(strings.Replace(hosts, "10.0.0.1", "10.0.0.2", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	remaining, err := fs.DiffTrees(context.Background(), third, newRoot, types.TreeDiffOptions{
+		Exclude: []string{"var/cache"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining.Changes) != 0 {
+		t.Errorf("expected the trees to match, got %+v", remaining.Changes)
+	}
+
+	// applying it again conflicts
+	err = fs.ApplyChangeset(context.Background(), third, changeset)
+	if !errors.Is(err, fs.ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+}
+
+func TestApplyChangesetSymlinkEscape(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	outside := filepath.Join(dir, "outside")
+	writeTree(t, root, map[string]string{"keep": ""})
+	if err := os.Mkdir(outside, 0755); err != nil {
+		t.Fatal(err)
+	}
+	file := &types.FileMeta{Type: types.FileTypeRegular, Mode: 0644, Size: 5}
+
+	// a symlink added by the changeset itself
+	changeset := &types.Changeset{Changes: []types.TreeChange{
+		{Path: "a", Kind: types.ChangeAdded, New: &types.FileMeta{Type: types.FileTypeSymlink, Mode: 0777, Target: outside}},
+		{Path: "a/file", Kind: types.ChangeAdded, New: file, Content: []byte("owned")},
+	}}
+	if err := fs.ApplyChangeset(context.Background(), root, changeset); err == nil {
+		t.Error("expected an error for a change under a symlink of the changeset")
+	}
+
+	// a symlink already in the tree
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+	changeset = &types.Changeset{Changes: []types.TreeChange{
+		{Path: "link/other", Kind: types.ChangeAdded, New: file, Content: []byte("owned")},
+	}}
+	if err := fs.ApplyChangeset(context.Background(), root, changeset); err == nil {
+		t.Error("expected an error for a change under a symlink of the tree")
+	}
+
+	for _, name := range []string{"file", "other"} {
+		if _, err := os.Stat(filepath.Join(outside, name)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s was written outside the root: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs"
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+)
+
+// walkPaths returns the relative paths reported by Walk, failing the test
+// on errors.
+func walkPaths(t *testing.T, root string, options types.WalkOptions) []string {
+	t.Helper()
+
+	options.RelativePaths = true
+	var paths []string
+	for file, err := range fs.Walk(context.Background(), root, options) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, filepath.ToSlash(file.Path))
+	}
+	return paths
+}
+
+func TestWalkFilters(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"README.md":             "",
+		"main.go":               "",
+		"main_test.go":          "",
+		"cmd/tool/tool.go":      "",
+		"docs/guide.md":         "",
+		"vendor/lib/lib.go":     "",
+		"node_modules/x/x.js":   "",
+		"build/out.bin":         "",
+		"build/keep.txt":        "",
+		"logs/app.log":          "",
+		"logs/important.log":    "",
+		"sub/.gitignore":        "*.tmp\n/local\n",
+		"sub/a.tmp":             "",
+		"sub/local/file":        "",
+		"sub/deep/local/file":   "",
+		"sub/deep/b.tmp":        "",
+		".gitignore":            "# build output\nbuild/\n*.log\n!important.log\nnode_modules\ndocs/**/*.md\n",
+		"sub/deep/kept.txt":     "",
+		"cmd/tool/tool_test.go": "",
+	})
+
+	got := walkPaths(t, root, types.WalkOptions{
+		IgnoreFiles:   []string{".gitignore"},
+		Exclude:       []string{"vendor"},
+		ExcludeRegexp: regexp.MustCompile(`_test\.go$`),
+		NoDirectories: true,
+	})
+	expected := []string{
+		".gitignore",
+		"README.md",
+		"cmd/tool/tool.go",
+		"logs/important.log",
+		"main.go",
+		"sub/.gitignore",
+		"sub/deep/kept.txt",
+		"sub/deep/local/file",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	got = walkPaths(t, root, types.WalkOptions{
+		Include:       []string{"*.go"},
+		IncludeRegexp: regexp.MustCompile(`^(cmd|vendor)/`),
+		NoDirectories: true,
+	})
+	expected = []string{"cmd/tool/tool.go", "cmd/tool/tool_test.go", "vendor/lib/lib.go"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestWalkDepthAndSymlinks(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"a/b/c/file": "", "top": ""})
+	if err := os.Symlink("a/b", filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("..", filepath.Join(root, "a", "up")); err != nil {
+		t.Fatal(err)
+	}
+
+	got := walkPaths(t, root, types.WalkOptions{MaxDepth: 2})
+	expected := []string{"a", "a/b", "a/up", "link", "top"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	got = walkPaths(t, root, types.WalkOptions{Symlinks: types.SymlinksSkip, NoDirectories: true})
+	expected = []string{"a/b/c/file", "top"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	// followed symlinks are walked, loops are reported and not walked
+	var paths []string
+	var loops int
+	for file, err := range fs.Walk(context.Background(), root, types.WalkOptions{
+		Symlinks:      types.SymlinksFollow,
+		RelativePaths: true,
+		NoDirectories: true,
+	}) {
+		if err != nil {
+			if !strings.Contains(err.Error(), "symlink loop") {
+				t.Fatal(err)
+			}
+			loops++
+			continue
+		}
+		paths = append(paths, file.Path)
+	}
+	expected = []string{"a/b/c/file", "link/c/file", "top"}
+	if !reflect.DeepEqual(paths, expected) || loops != 1 {
+		t.Errorf("expected %v and a loop, got %v and %d loops", expected, paths, loops)
+	}
+}
+
+func TestWalkSameFilesystem(t *testing.T) {
+	other, err := os.MkdirTemp("/dev/shm", "walk-")
+	if err != nil {
+		t.Skip("no second filesystem available")
+	}
+	defer os.RemoveAll(other)
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"file": ""})
+	writeTree(t, other, map[string]string{"elsewhere": ""})
+	if err := os.Symlink(other, filepath.Join(root, "mnt")); err != nil {
+		t.Fatal(err)
+	}
+
+	got := walkPaths(t, root, types.WalkOptions{Symlinks: types.SymlinksFollow, SameFilesystem: true})
+	expected := []string{"file", "mnt"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestWalkParallelAndStop(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		files[name+"/1"] = name
+		files[name+"/2"] = name
+	}
+	writeTree(t, root, files)
+
+	sequential := walkPaths(t, root, types.WalkOptions{Info: types.FileInfoOptions{Hash: true}})
+	parallel := walkPaths(t, root, types.WalkOptions{Parallel: 4, Info: types.FileInfoOptions{Hash: true}})
+	if len(sequential) != 24 || !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("parallel walk differs: %v and %v", sequential, parallel)
+	}
+
+	count := 0
+	for range fs.Walk(context.Background(), root, types.WalkOptions{}) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("expected the walk to stop after 3 entries, got %d", count)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range fs.Walk(ctx, root, types.WalkOptions{}) {
+		if err != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	}
+}
+
+func TestGetFileListDepth(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"a": "", "b": "", "sub/c": ""})
+
+	files, err := fs.GetFileList(root, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[0].Path != "a" || files[1].Path != "b" {
+		t.Errorf("expected the files of the root only, got %+v", files)
+	}
+
+	files, err = fs.GetFileList(root, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 || files[2].Path != filepath.Join(root, "sub", "c") || files[2].ParentPath != filepath.Join(root, "sub") {
+		t.Errorf("unexpected recursive list: %+v", files)
+	}
+}
@@ -0,0 +1,247 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs"
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+	"github.com/vanilla-os/sdk/pkg/v1/goodies"
+)
+
+// expectEvent waits for an event of a path holding an operation, failing
+// the test if it does not arrive in time.
+func expectEvent(t *testing.T, events <-chan types.WatchEvent, path string, op types.WatchOp) types.WatchEvent {
+	t.Helper()
+
+	timeout := time.After(3 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("events channel closed while waiting for %s on %s", op, path)
+			}
+			if event.Path == path && event.Op.Has(op) {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s on %s", op, path)
+		}
+	}
+}
+
+// collectEvents returns the events received within a duration.
+func collectEvents(events <-chan types.WatchEvent, d time.Duration) []types.WatchEvent {
+	var collected []types.WatchEvent
+	timeout := time.After(d)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return collected
+			}
+			collected = append(collected, event)
+		case <-timeout:
+			return collected
+		}
+	}
+}
+
+func TestWatchRecursive(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"sub/keep.txt": "keep"})
+	if err := os.Mkdir(filepath.Join(root, "cache"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := fs.Watch(context.Background(), root, types.WatchOptions{
+		Recursive: true,
+		Exclude:   []string{"cache"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+	events := watcher.Events()
+
+	// changes in an existing subdirectory
+	file := filepath.Join(root, "sub", "a.txt")
+	if err := os.WriteFile(file, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, events, file, types.WatchCreate)
+
+	renamed := filepath.Join(root, "sub", "b.txt")
+	if err := os.Rename(file, renamed); err != nil {
+		t.Fatal(err)
+	}
+	event := expectEvent(t, events, renamed, types.WatchRename)
+	if event.OldPath != file {
+		t.Errorf("expected old path %s, got %s", file, event.OldPath)
+	}
+
+	// directories created while watching are watched too
+	nested := filepath.Join(root, "new", "deep")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, events, filepath.Join(root, "new"), types.WatchCreate)
+	nestedFile := filepath.Join(nested, "c.txt")
+	if err := os.WriteFile(nestedFile, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, events, nestedFile, types.WatchCreate)
+
+	// renamed directories keep being watched under their new path
+	moved := filepath.Join(root, "moved")
+	if err := os.Rename(filepath.Join(root, "sub"), moved); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, events, moved, types.WatchRename)
+	if err := os.Remove(filepath.Join(moved, "keep.txt")); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, events, filepath.Join(moved, "keep.txt"), types.WatchRemove)
+
+	// excluded directories are not reported
+	if err := os.WriteFile(filepath.Join(root, "cache", "blob"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, event := range collectEvents(events, 200*time.Millisecond) {
+		if filepath.Base(filepath.Dir(event.Path)) == "cache" {
+			t.Errorf("unexpected event in an excluded directory: %+v", event)
+		}
+	}
+}
+
+func TestWatchDebounce(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "config")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := fs.Watch(context.Background(), root, types.WatchOptions{Debounce: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		f.WriteString("line\n")
+	}
+	f.Close()
+	if err := os.Chmod(file, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// a file created and removed before the delivery is not reported
+	temp := filepath.Join(root, "temp")
+	if err := os.WriteFile(temp, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(temp); err != nil {
+		t.Fatal(err)
+	}
+
+	events := collectEvents(watcher.Events(), 500*time.Millisecond)
+	if len(events) != 1 {
+		t.Fatalf("expected a single coalesced event, got %+v", events)
+	}
+	if events[0].Path != file || events[0].Op != types.WatchWrite|types.WatchChmod {
+		t.Errorf("unexpected coalesced event: %+v", events[0])
+	}
+}
+
+func TestWatchFileReplaced(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "settings.json")
+	if err := os.WriteFile(file, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := fs.Watch(context.Background(), file, types.WatchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	if err := os.WriteFile(filepath.Join(root, "other"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.AtomicWriteFile(file, []byte(`{"theme":"dark"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	event := expectEvent(t, watcher.Events(), file, types.WatchCreate)
+	if event.IsDirectory {
+		t.Errorf("unexpected directory flag: %+v", event)
+	}
+	for _, event := range collectEvents(watcher.Events(), 200*time.Millisecond) {
+		if event.Path != file {
+			t.Errorf("unexpected event for another file: %+v", event)
+		}
+	}
+}
+
+func TestWatchEventManager(t *testing.T) {
+	root := t.TempDir()
+	received := make(chan types.WatchEvent, 8)
+
+	manager := goodies.NewEventManager()
+	manager.Subscribe(types.WatchEventName, func(data interface{}) {
+		received <- data.(types.WatchEvent)
+	})
+
+	watcher, err := fs.Watch(context.Background(), root, types.WatchOptions{EventManager: manager})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	file := filepath.Join(root, "notified")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, received, file, types.WatchCreate)
+}
+
+func TestWatchCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher, err := fs.Watch(ctx, t.TempDir(), types.WatchOptions{Recursive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-watcher.Events():
+		if ok {
+			t.Error("unexpected event after the cancellation")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("events channel not closed after the cancellation")
+	}
+	if err := watcher.Close(); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := fs.Watch(context.Background(), filepath.Join(t.TempDir(), "missing"), types.WatchOptions{}); err == nil {
+		t.Error("expected an error watching a missing path")
+	}
+}
@@ -0,0 +1,596 @@
+package fs
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Diff and patch of directory trees.
+*/
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+	"golang.org/x/sys/unix"
+)
+
+// ErrConflict is returned by ApplyChangeset when the tree does not match
+// the old side of a change.
+var ErrConflict = errors.New("changeset conflicts with the tree")
+
+// DiffTrees compares two directory trees and returns the files added,
+// removed and modified in newRoot compared to oldRoot. Files are compared
+// by type, mode, owner, extended attributes, symlink target and content;
+// timestamps are ignored. Modified text files carry a unified diff, added
+// and binary files their new content, so that the changeset can be applied
+// to a third tree with ApplyChangeset.
+//
+// Example:
+//
+//	changeset, err := fs.DiffTrees(ctx, "/var/lib/images/old", "/var/lib/images/new", types.TreeDiffOptions{
+//		Exclude: []string{"var/cache"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, change := range changeset.Changes {
+//		fmt.Printf("%s %s %v\n", change.Kind, change.Path, change.Fields)
+//	}
+func DiffTrees(ctx context.Context, oldRoot, newRoot string, options types.TreeDiffOptions) (*types.Changeset, error) {
+	oldFiles, err := scanTree(ctx, oldRoot, options)
+	if err != nil {
+		return nil, err
+	}
+	newFiles, err := scanTree(ctx, newRoot, options)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := slices.Collect(maps.Keys(oldFiles))
+	for p := range newFiles {
+		if _, ok := oldFiles[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	slices.Sort(paths)
+
+	changeset := &types.Changeset{Changes: []types.TreeChange{}}
+	for _, p := range paths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		oldMeta, newMeta := oldFiles[p], newFiles[p]
+		change := types.TreeChange{Path: p, Old: oldMeta, New: newMeta}
+
+		switch {
+		case oldMeta == nil:
+			change.Kind = types.ChangeAdded
+			if newMeta.Type == types.FileTypeRegular && !options.OmitContent {
+				if change.Content, err = readTreeFile(newRoot, p); err != nil {
+					return nil, err
+				}
+			}
+		case newMeta == nil:
+			change.Kind = types.ChangeRemoved
+		default:
+			change.Kind = types.ChangeModified
+			change.Fields = compareMeta(oldMeta, newMeta, options)
+			if len(change.Fields) == 0 {
+				continue
+			}
+			switch {
+			case slices.Contains(change.Fields, types.FieldType):
+				if newMeta.Type == types.FileTypeRegular && !options.OmitContent {
+					if change.Content, err = readTreeFile(newRoot, p); err != nil {
+						return nil, err
+					}
+				}
+			case slices.Contains(change.Fields, types.FieldContent):
+				if err := diffContent(&change, oldRoot, newRoot, options); err != nil {
+					return nil, err
+				}
+			}
+		}
+		changeset.Changes = append(changeset.Changes, change)
+	}
+	return changeset, nil
+}
+
+// scanTree returns the metadata of the files of a tree by their slash
+// separated relative path, the root excluded.
+func scanTree(ctx context.Context, root string, options types.TreeDiffOptions) (map[string]*types.FileMeta, error) {
+	files := make(map[string]*types.FileMeta)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if matchesPattern(d.Name(), rel, options.Exclude) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		meta, err := fileMeta(p, !options.IgnoreXattrs)
+		if err != nil {
+			return err
+		}
+		files[rel] = meta
+		return nil
+	})
+	return files, err
+}
+
+// fileMeta returns the metadata of a file, without following symlinks.
+func fileMeta(p string, withXattrs bool) (*types.FileMeta, error) {
+	info, err := os.Lstat(p)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &types.FileMeta{
+		Type: fileType(info.Mode()),
+		Mode: info.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky),
+	}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		meta.UID, meta.GID = int(st.Uid), int(st.Gid)
+		if meta.Type == types.FileTypeChar || meta.Type == types.FileTypeBlock {
+			meta.Rdev = uint64(st.Rdev)
+		}
+	}
+
+	switch meta.Type {
+	case types.FileTypeRegular:
+		meta.Size = info.Size()
+		if meta.Hash, err = hashFile(p); err != nil {
+			return nil, err
+		}
+	case types.FileTypeSymlink:
+		if meta.Target, err = os.Readlink(p); err != nil {
+			return nil, err
+		}
+	}
+
+	if withXattrs {
+		attrs, err := readXattrs(p, false)
+		if err != nil {
+			return nil, err
+		}
+		if len(attrs) > 0 {
+			meta.Xattrs = attrs
+		}
+	}
+	return meta, nil
+}
+
+// fileType returns the type of a file from its mode.
+func fileType(mode fs.FileMode) types.FileType {
+	switch {
+	case mode.IsDir():
+		return types.FileTypeDirectory
+	case mode&fs.ModeSymlink != 0:
+		return types.FileTypeSymlink
+	case mode&fs.ModeNamedPipe != 0:
+		return types.FileTypeFIFO
+	case mode&fs.ModeSocket != 0:
+		return types.FileTypeSocket
+	case mode&fs.ModeCharDevice != 0:
+		return types.FileTypeChar
+	case mode&fs.ModeDevice != 0:
+		return types.FileTypeBlock
+	}
+	return types.FileTypeRegular
+}
+
+// hashFile returns the hex encoded SHA-256 checksum of a file.
+func hashFile(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// compareMeta returns the properties which differ between two files.
+func compareMeta(oldMeta, newMeta *types.FileMeta, options types.TreeDiffOptions) []types.ChangeField {
+	var fields []types.ChangeField
+	if oldMeta.Type != newMeta.Type {
+		return []types.ChangeField{types.FieldType}
+	}
+	if oldMeta.Mode != newMeta.Mode && oldMeta.Type != types.FileTypeSymlink {
+		fields = append(fields, types.FieldMode)
+	}
+	if !options.IgnoreOwner && (oldMeta.UID != newMeta.UID || oldMeta.GID != newMeta.GID) {
+		fields = append(fields, types.FieldOwner)
+	}
+	if !options.IgnoreXattrs && !maps.EqualFunc(oldMeta.Xattrs, newMeta.Xattrs, bytes.Equal) {
+		fields = append(fields, types.FieldXattrs)
+	}
+	if oldMeta.Target != newMeta.Target || oldMeta.Rdev != newMeta.Rdev {
+		fields = append(fields, types.FieldTarget)
+	}
+	if oldMeta.Hash != newMeta.Hash {
+		fields = append(fields, types.FieldContent)
+	}
+	return fields
+}
+
+// diffContent fills the unified diff of a modified text file, or the new
+// content of a binary one.
+func diffContent(change *types.TreeChange, oldRoot, newRoot string, options types.TreeDiffOptions) error {
+	oldData, err := readTreeFile(oldRoot, change.Path)
+	if err != nil {
+		return err
+	}
+	newData, err := readTreeFile(newRoot, change.Path)
+	if err != nil {
+		return err
+	}
+
+	if IsBinary(oldData) || IsBinary(newData) {
+		change.Binary = true
+		if !options.OmitContent {
+			change.Content = newData
+		}
+		return nil
+	}
+	context := options.Context
+	if context <= 0 {
+		context = DefaultDiffContext
+	}
+	change.Diff = DiffText("a/"+change.Path, "b/"+change.Path, string(oldData), string(newData), context).Unified()
+	return nil
+}
+
+// readTreeFile reads a file of a tree by its relative path.
+func readTreeFile(root, rel string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+}
+
+// ApplyChangeset applies a changeset, usually returned by DiffTrees, to the
+// tree at root. Each change is checked against the tree first: removed and
+// modified files must exist with the old type and content, added files
+// must not exist, otherwise an error wrapping ErrConflict is returned.
+// Text files are patched with their diff, so they may differ from the old
+// tree elsewhere. The owner is applied only when permitted.
+//
+// Example:
+//
+//	f, err := os.Open("update.json")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	changeset, err := fs.ReadChangeset(f)
+//	if err != nil {
+//		return err
+//	}
+//	err = fs.ApplyChangeset(ctx, "/var/lib/images/staging", changeset)
+func ApplyChangeset(ctx context.Context, root string, changeset *types.Changeset) error {
+	// symlinks created by the changeset must not lead its other changes
+	// outside the tree, the ones already in the tree are checked later
+	var links []string
+	for _, change := range changeset.Changes {
+		if change.Kind != types.ChangeRemoved && change.New != nil && change.New.Type == types.FileTypeSymlink {
+			links = append(links, change.Path)
+		}
+	}
+	for _, change := range changeset.Changes {
+		for _, link := range links {
+			if change.Kind != types.ChangeRemoved && strings.HasPrefix(change.Path, link+"/") {
+				return fmt.Errorf("%s: invalid path in changeset, %s is a symlink", change.Path, link)
+			}
+		}
+		if err := checkChange(root, change); err != nil {
+			return err
+		}
+	}
+
+	// removals go first, deepest first, then the other changes with the
+	// parents before their content
+	changes := slices.Clone(changeset.Changes)
+	slices.SortStableFunc(changes, func(a, b types.TreeChange) int {
+		aRemoved, bRemoved := a.Kind == types.ChangeRemoved, b.Kind == types.ChangeRemoved
+		switch {
+		case aRemoved && bRemoved:
+			return strings.Compare(b.Path, a.Path)
+		case aRemoved:
+			return -1
+		case bRemoved:
+			return 1
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	var dirs []types.TreeChange
+	for _, change := range changes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// checked again, earlier changes may have replaced a parent
+		target, err := treePath(root, change.Path)
+		if err != nil {
+			return err
+		}
+
+		switch change.Kind {
+		case types.ChangeRemoved:
+			err = os.RemoveAll(target)
+		case types.ChangeAdded:
+			err = createFromMeta(target, change.New, change.Content)
+		case types.ChangeModified:
+			err = applyModified(target, change)
+		default:
+			err = fmt.Errorf("%s: unknown change %q", change.Path, change.Kind)
+		}
+		if err != nil {
+			return err
+		}
+
+		if change.Kind == types.ChangeRemoved {
+			continue
+		}
+		// the metadata of directories is applied last, so that read-only
+		// ones can still be filled
+		if change.New.Type == types.FileTypeDirectory {
+			dirs = append(dirs, change)
+			continue
+		}
+		if err := applyMeta(target, change.New); err != nil {
+			return err
+		}
+	}
+
+	for i := len(dirs) - 1; i >= 0; i-- {
+		target, err := treePath(root, dirs[i].Path)
+		if err != nil {
+			return err
+		}
+		if err := applyMeta(target, dirs[i].New); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkChange verifies that a change applies to the tree.
+func checkChange(root string, change types.TreeChange) error {
+	if change.Path == "" || path.IsAbs(change.Path) || slices.Contains(strings.Split(change.Path, "/"), "..") {
+		return fmt.Errorf("%s: invalid path in changeset", change.Path)
+	}
+	conflict := func(reason string) error {
+		return fmt.Errorf("%s: %s: %w", change.Path, reason, ErrConflict)
+	}
+
+	target, err := treePath(root, change.Path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Lstat(target)
+	exists := err == nil
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	switch change.Kind {
+	case types.ChangeAdded:
+		if change.New == nil {
+			return fmt.Errorf("%s: added file without metadata", change.Path)
+		}
+		if exists {
+			return conflict("already exists")
+		}
+		if change.New.Type == types.FileTypeRegular && change.Content == nil && change.New.Size > 0 {
+			return fmt.Errorf("%s: content not included in the changeset", change.Path)
+		}
+		return nil
+	case types.ChangeRemoved, types.ChangeModified:
+		if change.Old == nil || (change.Kind == types.ChangeModified && change.New == nil) {
+			return fmt.Errorf("%s: %s file without metadata", change.Path, change.Kind)
+		}
+		if !exists {
+			return conflict("does not exist")
+		}
+		if fileType(info.Mode()) != change.Old.Type {
+			return conflict("has type " + string(fileType(info.Mode())))
+		}
+	}
+
+	if change.Kind != types.ChangeModified {
+		return nil
+	}
+	if slices.Contains(change.Fields, types.FieldType) {
+		if change.New.Type == types.FileTypeRegular && change.Content == nil && change.New.Size > 0 {
+			return fmt.Errorf("%s: content not included in the changeset", change.Path)
+		}
+		return nil
+	}
+	if !slices.Contains(change.Fields, types.FieldContent) {
+		return nil
+	}
+	if change.Diff == "" && change.Content == nil && change.New.Size > 0 {
+		return fmt.Errorf("%s: content not included in the changeset", change.Path)
+	}
+	if change.Diff == "" && change.Old.Hash != "" {
+		// binary content is replaced, it must be the old one
+		hash, err := hashFile(target)
+		if err != nil {
+			return err
+		}
+		if hash != change.Old.Hash {
+			return conflict("content differs")
+		}
+	}
+	return nil
+}
+
+// treePath returns the path of a file of the tree at root, given its
+// slash separated relative path. The parents of the file are resolved
+// without following symlinks, which could lead outside the tree: a parent
+// being a symlink is an error.
+func treePath(root, rel string) (string, error) {
+	parts := strings.Split(rel, "/")
+	current := root
+	for i, part := range parts[:len(parts)-1] {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return "", fmt.Errorf("%s: invalid path in changeset, %s is a symlink", rel, strings.Join(parts[:i+1], "/"))
+		}
+	}
+	return filepath.Join(root, filepath.FromSlash(rel)), nil
+}
+
+// applyModified applies the content, type and target changes of a file,
+// the other metadata is applied by applyMeta.
+func applyModified(target string, change types.TreeChange) error {
+	if slices.Contains(change.Fields, types.FieldType) || slices.Contains(change.Fields, types.FieldTarget) {
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		return createFromMeta(target, change.New, change.Content)
+	}
+	if !slices.Contains(change.Fields, types.FieldContent) {
+		return nil
+	}
+
+	content := change.Content
+	if change.Diff != "" {
+		current, err := os.ReadFile(target)
+		if err != nil {
+			return err
+		}
+		patched, err := applyUnified(string(current), change.Diff)
+		if err != nil {
+			return fmt.Errorf("%s: %v: %w", change.Path, err, ErrConflict)
+		}
+		content = []byte(patched)
+	}
+	return AtomicWriteFile(target, content, change.New.Mode)
+}
+
+// createFromMeta creates a file of any type from its metadata.
+func createFromMeta(target string, meta *types.FileMeta, content []byte) error {
+	switch meta.Type {
+	case types.FileTypeDirectory:
+		return os.Mkdir(target, 0700)
+	case types.FileTypeRegular:
+		return os.WriteFile(target, content, 0600)
+	case types.FileTypeSymlink:
+		return os.Symlink(meta.Target, target)
+	}
+
+	mode := uint32(meta.Mode.Perm())
+	switch meta.Type {
+	case types.FileTypeFIFO:
+		mode |= unix.S_IFIFO
+	case types.FileTypeSocket:
+		mode |= unix.S_IFSOCK
+	case types.FileTypeChar:
+		mode |= unix.S_IFCHR
+	case types.FileTypeBlock:
+		mode |= unix.S_IFBLK
+	default:
+		return fmt.Errorf("%s: unknown file type %q", target, meta.Type)
+	}
+	if err := unix.Mknod(target, mode, int(meta.Rdev)); err != nil {
+		return &os.PathError{Op: "mknod", Path: target, Err: err}
+	}
+	return nil
+}
+
+// applyMeta applies the extended attributes, the owner and the mode of a
+// file, the owner only when permitted.
+func applyMeta(target string, meta *types.FileMeta) error {
+	current, err := readXattrs(target, false)
+	if err != nil {
+		return err
+	}
+	for name := range current {
+		if _, ok := meta.Xattrs[name]; !ok {
+			err := unix.Lremovexattr(target, name)
+			if err != nil && !errors.Is(err, unix.EPERM) && !errors.Is(err, unix.ENOTSUP) {
+				return &os.PathError{Op: "removexattr", Path: target, Err: err}
+			}
+		}
+	}
+	if err := writeXattrs(target, meta.Xattrs, false); err != nil {
+		return err
+	}
+
+	if err := os.Lchown(target, meta.UID, meta.GID); err != nil && !errors.Is(err, fs.ErrPermission) {
+		return err
+	}
+	if meta.Type == types.FileTypeSymlink {
+		return nil
+	}
+	return os.Chmod(target, meta.Mode)
+}
+
+// WriteChangeset writes a changeset as indented JSON.
+//
+// Example:
+//
+//	f, err := os.Create("update.json")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	err = fs.WriteChangeset(f, changeset)
+func WriteChangeset(w io.Writer, changeset *types.Changeset) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(changeset)
+}
+
+// ReadChangeset reads a changeset written by WriteChangeset.
+//
+// Example:
+//
+//	changeset, err := fs.ReadChangeset(f)
+//	if err != nil {
+//		return err
+//	}
+func ReadChangeset(r io.Reader) (*types.Changeset, error) {
+	var changeset types.Changeset
+	if err := json.NewDecoder(r).Decode(&changeset); err != nil {
+		return nil, fmt.Errorf("invalid changeset: %w", err)
+	}
+	return &changeset, nil
+}
@@ -0,0 +1,62 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+// CopyOptions configures CopyFileWithOptions and CopyTree. The zero value
+// overwrites existing files and copies symlinks as symlinks.
+type CopyOptions struct {
+	// Include lists the glob patterns of the files to copy, matched against
+	// the name or the slash separated path relative to the source root
+	// (e.g. "*.conf" or "etc/*.d"). If empty, all files are copied.
+	// Directories are always traversed unless excluded
+	Include []string
+
+	// Exclude lists the glob patterns of the files and directories to skip,
+	// matched as Include. Excluded directories are not traversed
+	Exclude []string
+
+	// NoOverwrite makes the copy fail with fs.ErrExist instead of replacing
+	// an existing destination file
+	NoOverwrite bool
+
+	// FollowSymlinks copies the targets of the symlinks instead of the
+	// symlinks themselves
+	FollowSymlinks bool
+
+	// Progress, if set, is called while copying, after each chunk of data
+	// and after each file
+	Progress func(progress CopyProgress)
+}
+
+// CopyProgress reports the progress of a copy.
+type CopyProgress struct {
+	// Path is the source path of the file being copied
+	Path string
+
+	// BytesCopied and BytesTotal are the bytes of the regular files copied
+	// so far and to copy in total
+	BytesCopied int64
+	BytesTotal  int64
+
+	// FilesCopied and FilesTotal are the entries copied so far and to copy
+	// in total, directories excluded
+	FilesCopied int
+	FilesTotal  int
+}
+
+// MoveOptions configures MoveFileWithOptions.
+type MoveOptions struct {
+	// NoOverwrite makes the move fail with fs.ErrExist instead of replacing
+	// an existing destination
+	NoOverwrite bool
+
+	// Progress, if set, is called while copying when the source and the
+	// destination are on different filesystems
+	Progress func(progress CopyProgress)
+}
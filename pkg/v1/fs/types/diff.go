@@ -0,0 +1,162 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NoNewlineMarker marks the lines of a unified diff missing the final
+// newline.
+const NoNewlineMarker = `\ No newline at end of file`
+
+// DiffLineKind is the kind of a line of a line diff, as prefixed in the
+// unified format.
+type DiffLineKind byte
+
+const (
+	// DiffContext is a line kept in both texts
+	DiffContext DiffLineKind = ' '
+
+	// DiffAdded is a line only in the new text
+	DiffAdded DiffLineKind = '+'
+
+	// DiffRemoved is a line only in the old text
+	DiffRemoved DiffLineKind = '-'
+)
+
+// DiffLine is a line of a line diff.
+type DiffLine struct {
+	// Kind is the kind of the line
+	Kind DiffLineKind
+
+	// Text is the content of the line, including the newline unless it is
+	// the last line of a text not ending with one
+	Text string
+
+	// OldLine and NewLine are the 1-based numbers of the line in the old
+	// and in the new text, 0 if it is not in that text
+	OldLine int
+	NewLine int
+}
+
+// DiffHunk is a group of changed lines with their context.
+type DiffHunk struct {
+	// OldStart and OldLines are the first line and the number of lines
+	// of the hunk in the old text; an empty hunk starts at the line
+	// preceding it, as in the unified format
+	OldStart int
+	OldLines int
+
+	// NewStart and NewLines are the same for the new text
+	NewStart int
+	NewLines int
+
+	// Lines are the lines of the hunk
+	Lines []DiffLine
+}
+
+// Header returns the header of the hunk in the unified format.
+//
+// Example:
+//
+//	fmt.Println(hunk.Header()) // @@ -1,4 +1,5 @@
+func (h DiffHunk) Header() string {
+	return fmt.Sprintf("@@ -%s +%s @@", hunkRange(h.OldStart, h.OldLines), hunkRange(h.NewStart, h.NewLines))
+}
+
+// hunkRange formats the range of a hunk header, omitting a count of 1.
+func hunkRange(start, lines int) string {
+	if lines == 1 {
+		return strconv.Itoa(start)
+	}
+	return strconv.Itoa(start) + "," + strconv.Itoa(lines)
+}
+
+// LineDiff is the line-level diff between two texts.
+type LineDiff struct {
+	// OldName and NewName are the names of the compared texts, usually
+	// their paths
+	OldName string
+	NewName string
+
+	// Binary is true if either text is binary, in which case no hunks are
+	// computed
+	Binary bool
+
+	// Equal is true if the texts are the same
+	Equal bool
+
+	// Hunks are the changed lines with their context
+	Hunks []DiffHunk
+}
+
+// Added returns the number of added lines.
+//
+// Example:
+//
+//	fmt.Printf("+%d -%d\n", diff.Added(), diff.Removed())
+func (d LineDiff) Added() int {
+	return d.count(DiffAdded)
+}
+
+// Removed returns the number of removed lines.
+//
+// Example:
+//
+//	fmt.Printf("+%d -%d\n", diff.Added(), diff.Removed())
+func (d LineDiff) Removed() int {
+	return d.count(DiffRemoved)
+}
+
+// count returns the number of lines of a kind.
+func (d LineDiff) count(kind DiffLineKind) int {
+	n := 0
+	for _, h := range d.Hunks {
+		for _, line := range h.Lines {
+			if line.Kind == kind {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Unified returns the diff in the unified format, as printed by diff -u,
+// or an empty string if the texts are equal. Binary texts are reported as
+// diff does, without their content.
+//
+// Example:
+//
+//	fmt.Print(diff.Unified())
+func (d LineDiff) Unified() string {
+	if d.Equal {
+		return ""
+	}
+	if d.Binary {
+		return fmt.Sprintf("Binary files %s and %s differ\n", d.OldName, d.NewName)
+	}
+
+	var b strings.Builder
+	b.WriteString("--- " + d.OldName + "\n")
+	b.WriteString("+++ " + d.NewName + "\n")
+	for _, h := range d.Hunks {
+		b.WriteString(h.Header() + "\n")
+		for _, line := range h.Lines {
+			b.WriteByte(byte(line.Kind))
+			b.WriteString(line.Text)
+			if !strings.HasSuffix(line.Text, "\n") {
+				b.WriteString("\n" + NoNewlineMarker + "\n")
+			}
+		}
+	}
+	return b.String()
+}
@@ -8,6 +8,11 @@ package types
 	Description: Vanilla OS SDK component.
 */
 
+import (
+	"io/fs"
+	"time"
+)
+
 // FileInfo represents information about a file
 type FileInfo struct {
 	// Path to the file
@@ -27,6 +32,56 @@ type FileInfo struct {
 
 	// Extension of the file (e.g. "txt")
 	Extension string
+
+	// Mode is the full mode of the file, including its type and the
+	// setuid, setgid and sticky bits
+	Mode fs.FileMode
+
+	// IsSymlink is true if the file is a symbolic link, LinkTarget is
+	// where it points to
+	IsSymlink  bool
+	LinkTarget string
+
+	// UID and GID are the numeric owner and group of the file, Owner and
+	// Group their names, empty if they do not exist on the system
+	UID   uint32
+	GID   uint32
+	Owner string
+	Group string
+
+	// ModTime, AccessTime and ChangeTime are the last modification of the
+	// content, access and change of the metadata of the file
+	ModTime    time.Time
+	AccessTime time.Time
+	ChangeTime time.Time
+
+	// BirthTime is the creation time of the file, zero if the filesystem
+	// does not record it
+	BirthTime time.Time
+
+	// Device and Inode identify the file on the system, Links is the
+	// number of hard links to it
+	Device uint64
+	Inode  uint64
+	Links  uint64
+
+	// Xattrs are the extended attributes of the file, only read when
+	// requested with FileInfoOptions
+	Xattrs map[string][]byte
+
+	// Hash is the hex encoded SHA-256 of the content of a regular file,
+	// only computed when requested with FileInfoOptions
+	Hash string
+}
+
+// FileInfoOptions selects the expensive information read by
+// fs.GetFileWithOptions and fs.GetFileListWithOptions.
+type FileInfoOptions struct {
+	// Xattrs reads the extended attributes of the files
+	Xattrs bool
+
+	// Hash computes the SHA-256 of the content of the regular files
+	Hash bool
 }
 
 // FileDiff represents the difference between two files.
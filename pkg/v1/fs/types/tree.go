@@ -0,0 +1,128 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import "io/fs"
+
+// FileType is the type of a file in a tree.
+type FileType string
+
+const (
+	FileTypeRegular   FileType = "file"
+	FileTypeDirectory FileType = "directory"
+	FileTypeSymlink   FileType = "symlink"
+	FileTypeFIFO      FileType = "fifo"
+	FileTypeSocket    FileType = "socket"
+	FileTypeChar      FileType = "char"
+	FileTypeBlock     FileType = "block"
+)
+
+// FileMeta holds the metadata of a file compared by a tree diff.
+type FileMeta struct {
+	// Type is the type of the file
+	Type FileType `json:"type"`
+
+	// Mode holds the permission bits, including setuid, setgid and sticky
+	Mode fs.FileMode `json:"mode"`
+
+	// UID and GID are the owner of the file
+	UID int `json:"uid"`
+	GID int `json:"gid"`
+
+	// Size is the size of regular files
+	Size int64 `json:"size,omitempty"`
+
+	// Hash is the SHA-256 checksum of regular files, hex encoded
+	Hash string `json:"hash,omitempty"`
+
+	// Target is the target of symlinks
+	Target string `json:"target,omitempty"`
+
+	// Rdev is the device number of character and block devices
+	Rdev uint64 `json:"rdev,omitempty"`
+
+	// Xattrs holds the extended attributes
+	Xattrs map[string][]byte `json:"xattrs,omitempty"`
+}
+
+// ChangeKind is the kind of a change in a tree.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// ChangeField is a property of a modified file.
+type ChangeField string
+
+const (
+	FieldType    ChangeField = "type"
+	FieldMode    ChangeField = "mode"
+	FieldOwner   ChangeField = "owner"
+	FieldXattrs  ChangeField = "xattrs"
+	FieldTarget  ChangeField = "target"
+	FieldContent ChangeField = "content"
+)
+
+// TreeChange is a change of a single file between two trees.
+type TreeChange struct {
+	// Path is the slash separated path relative to the roots of the trees
+	Path string `json:"path"`
+
+	// Kind is the kind of the change
+	Kind ChangeKind `json:"kind"`
+
+	// Old and New are the metadata of the file in the old and in the new
+	// tree, nil if it was added or removed
+	Old *FileMeta `json:"old,omitempty"`
+	New *FileMeta `json:"new,omitempty"`
+
+	// Fields lists the properties of a modified file that changed
+	Fields []ChangeField `json:"fields,omitempty"`
+
+	// Diff is the unified diff of a modified text file
+	Diff string `json:"diff,omitempty"`
+
+	// Binary is true if the content of a modified file is not text
+	Binary bool `json:"binary,omitempty"`
+
+	// Content is the new content of added regular files and of modified
+	// binary files, unless omitted with TreeDiffOptions.OmitContent
+	Content []byte `json:"content,omitempty"`
+}
+
+// Changeset holds the changes between two trees, sorted by path. It can be
+// serialized as JSON and applied to another tree.
+type Changeset struct {
+	Changes []TreeChange `json:"changes"`
+}
+
+// TreeDiffOptions configures DiffTrees.
+type TreeDiffOptions struct {
+	// Exclude lists the glob patterns of the files and directories to
+	// ignore, matched against the name or the slash separated relative
+	// path. Excluded directories are not traversed
+	Exclude []string
+
+	// IgnoreOwner does not compare the owners of the files
+	IgnoreOwner bool
+
+	// IgnoreXattrs does not compare the extended attributes of the files
+	IgnoreXattrs bool
+
+	// OmitContent leaves out the content of added and binary files, e.g.
+	// to only show the changes. Such a changeset cannot be applied
+	OmitContent bool
+
+	// Context is the number of context lines of the unified diffs, 3 if
+	// zero
+	Context int
+}
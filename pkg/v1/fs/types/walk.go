@@ -0,0 +1,78 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import "regexp"
+
+// SymlinkPolicy tells fs.Walk what to do with the symlinks it finds.
+type SymlinkPolicy int
+
+const (
+	// SymlinksReport reports the symlinks themselves without following
+	// them
+	SymlinksReport SymlinkPolicy = iota
+
+	// SymlinksFollow reports the targets of the symlinks, walking the
+	// linked directories too. Broken symlinks are reported themselves
+	SymlinksFollow
+
+	// SymlinksSkip omits the symlinks
+	SymlinksSkip
+)
+
+// WalkOptions configures fs.Walk. The zero value walks the whole tree,
+// reporting files and directories without following the symlinks.
+type WalkOptions struct {
+	// Include lists the glob patterns of the files to report, matched
+	// against the name or the slash separated path relative to the root
+	// (e.g. "*.conf" or "etc/*.d"). If empty, all files are reported.
+	// Directories are always walked unless excluded
+	Include []string
+
+	// Exclude lists the glob patterns of the files and directories to
+	// skip, matched as Include. Excluded directories are not walked
+	Exclude []string
+
+	// IncludeRegexp and ExcludeRegexp work like Include and Exclude,
+	// matched against the slash separated path relative to the root
+	IncludeRegexp *regexp.Regexp
+	ExcludeRegexp *regexp.Regexp
+
+	// IgnoreFiles lists the names of the files holding .gitignore-style
+	// patterns (e.g. ".gitignore"), read in each walked directory and
+	// applied to it and to its subdirectories
+	IgnoreFiles []string
+
+	// MaxDepth limits how deep the tree is walked, 1 meaning only the
+	// entries of the root. If zero, there is no limit
+	MaxDepth int
+
+	// SameFilesystem does not walk the directories on a filesystem other
+	// than the one of the root, as find -xdev does; the mount points are
+	// still reported
+	SameFilesystem bool
+
+	// Symlinks tells what to do with the symlinks
+	Symlinks SymlinkPolicy
+
+	// NoDirectories reports only the files, directories are still walked
+	NoDirectories bool
+
+	// RelativePaths reports the paths relative to the root instead of
+	// joined to it
+	RelativePaths bool
+
+	// Parallel is the number of files read concurrently in each directory;
+	// the files are reported in order anyway. If zero or one, they are
+	// read sequentially
+	Parallel int
+
+	// Info selects the expensive information read for each file
+	Info FileInfoOptions
+}
@@ -0,0 +1,109 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"strings"
+	"time"
+
+	"github.com/vanilla-os/sdk/pkg/v1/goodies"
+)
+
+// WatchEventName is the event type notified to the EventManager of
+// WatchOptions, unless EventName is set.
+const WatchEventName = "fs.watch"
+
+// WatchOp is a set of file operations reported by a watcher.
+type WatchOp uint32
+
+const (
+	// WatchCreate means that the file was created, or moved into the
+	// watched tree
+	WatchCreate WatchOp = 1 << iota
+
+	// WatchWrite means that the content of the file changed
+	WatchWrite
+
+	// WatchRemove means that the file was removed, or moved out of the
+	// watched tree
+	WatchRemove
+
+	// WatchRename means that the file was renamed from OldPath
+	WatchRename
+
+	// WatchChmod means that the metadata of the file changed, e.g. its
+	// permissions, owner or timestamps
+	WatchChmod
+)
+
+// Has reports whether the set contains an operation.
+//
+// Example:
+//
+//	if event.Op.Has(types.WatchWrite) {
+//		reloadConfig()
+//	}
+func (op WatchOp) Has(other WatchOp) bool {
+	return op&other != 0
+}
+
+// String returns the operations of the set separated by "|", e.g.
+// "create|write".
+func (op WatchOp) String() string {
+	names := []string{"create", "write", "remove", "rename", "chmod"}
+	var set []string
+	for i, name := range names {
+		if op.Has(1 << i) {
+			set = append(set, name)
+		}
+	}
+	return strings.Join(set, "|")
+}
+
+// WatchEvent is a change of a watched file.
+type WatchEvent struct {
+	// Path is the path of the changed file
+	Path string
+
+	// OldPath is the previous path of a renamed file
+	OldPath string
+
+	// Op holds the operations on the file; coalesced events may hold more
+	// than one, e.g. WatchCreate|WatchChmod
+	Op WatchOp
+
+	// IsDirectory is true if the file is a directory
+	IsDirectory bool
+}
+
+// WatchOptions configures fs.Watch. The zero value watches a single
+// directory and reports each change as soon as it is read.
+type WatchOptions struct {
+	// Recursive watches the subdirectories too, including the ones created
+	// while watching
+	Recursive bool
+
+	// Exclude lists the glob patterns of the files and directories to
+	// ignore, matched against the name or the slash separated path relative
+	// to the watched root. Excluded directories are not watched
+	Exclude []string
+
+	// Debounce, if set, delays the events until no change happens for the
+	// given duration, coalescing the changes of each file into a single
+	// event. Events are never delayed for more than ten times the duration
+	Debounce time.Duration
+
+	// EventManager, if set, receives the events instead of the Events
+	// channel of the watcher
+	EventManager *goodies.EventManager
+
+	// EventName is the event type notified to EventManager, WatchEventName
+	// if empty
+	EventName string
+}
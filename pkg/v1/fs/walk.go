@@ -0,0 +1,370 @@
+package fs
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Iterator based directory walking with filters.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+)
+
+// Walk returns an iterator over the files and directories under root, in
+// lexical order and with each directory reported before its content. The
+// root itself is not reported.
+//
+// Errors reading a file or a directory are yielded with a zero FileInfo;
+// the walk goes on, skipping what could not be read, unless the loop is
+// stopped. A canceled context is yielded as an error and ends the walk.
+//
+// Example:
+//
+//	for file, err := range fs.Walk(ctx, "/usr/share", types.WalkOptions{
+//		Include:        []string{"*.desktop"},
+//		IgnoreFiles:    []string{".gitignore"},
+//		SameFilesystem: true,
+//		NoDirectories:  true,
+//		Parallel:       runtime.NumCPU(),
+//	}) {
+//		if err != nil {
+//			fmt.Printf("Error: %v\n", err)
+//			continue
+//		}
+//		fmt.Println(file.Path)
+//	}
+func Walk(ctx context.Context, root string, options types.WalkOptions) iter.Seq2[types.FileInfo, error] {
+	return func(yield func(types.FileInfo, error) bool) {
+		info, err := os.Stat(root)
+		if err != nil {
+			yield(types.FileInfo{}, err)
+			return
+		}
+		if !info.IsDir() {
+			yield(types.FileInfo{}, &os.PathError{Op: "walk", Path: root, Err: syscall.ENOTDIR})
+			return
+		}
+
+		w := &walker{
+			ctx:     ctx,
+			options: options,
+			reader:  newFileInfoReader(options.Info),
+			yield:   yield,
+		}
+		rootID, _ := statID(info)
+		w.device = rootID.dev
+		if options.Symlinks == types.SymlinksFollow {
+			w.visiting = map[fileID]bool{rootID: true}
+		}
+		w.walkDir(root, "", 0, nil)
+	}
+}
+
+// walker holds the state of a Walk.
+type walker struct {
+	ctx      context.Context
+	options  types.WalkOptions
+	reader   *fileInfoReader
+	yield    func(types.FileInfo, error) bool
+	device   uint64
+	visiting map[fileID]bool
+}
+
+// walkEntry is an entry of a walked directory with its information.
+type walkEntry struct {
+	name string
+	rel  string
+	info types.FileInfo
+	err  error
+}
+
+// walkDir walks a directory, rel being its path relative to the root and
+// rules the ignore rules of its parents. It returns false if the walk must
+// stop.
+func (w *walker) walkDir(dir, rel string, depth int, rules []ignoreRule) bool {
+	if err := w.ctx.Err(); err != nil {
+		w.yield(types.FileInfo{}, err)
+		return false
+	}
+
+	rules, err := w.readIgnoreFiles(dir, rel, rules)
+	if err != nil && !w.yield(types.FileInfo{}, err) {
+		return false
+	}
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return w.yield(types.FileInfo{}, err)
+	}
+
+	var entries []walkEntry
+	for _, d := range dirEntries {
+		entryRel := filepath.Join(rel, d.Name())
+		if d.Type()&fs.ModeSymlink != 0 && w.options.Symlinks == types.SymlinksSkip {
+			continue
+		}
+		if w.excluded(d.Name(), entryRel) || ignored(rules, filepath.ToSlash(entryRel), d.IsDir()) {
+			continue
+		}
+		entries = append(entries, walkEntry{name: d.Name(), rel: entryRel})
+	}
+	w.stat(dir, entries)
+
+	for _, entry := range entries {
+		if entry.err != nil {
+			if !w.yield(types.FileInfo{}, entry.err) {
+				return false
+			}
+			continue
+		}
+
+		file := entry.info
+		if w.options.RelativePaths {
+			file.Path = entry.rel
+			file.ParentPath = filepath.Dir(entry.rel)
+		}
+		if file.IsDirectory {
+			if !w.options.NoDirectories && !w.yield(file, nil) {
+				return false
+			}
+		} else if w.included(entry.name, entry.rel) && !w.yield(file, nil) {
+			return false
+		}
+
+		if !file.IsDirectory || (w.options.MaxDepth > 0 && depth+1 >= w.options.MaxDepth) {
+			continue
+		}
+		if w.options.SameFilesystem && entry.info.Device != w.device {
+			continue
+		}
+		if !w.descend(filepath.Join(dir, entry.name), entry.rel, depth+1, rules, entry.info) {
+			return false
+		}
+	}
+	return true
+}
+
+// descend walks a subdirectory, detecting the loops of the followed
+// symlinks.
+func (w *walker) descend(dir, rel string, depth int, rules []ignoreRule, info types.FileInfo) bool {
+	if w.visiting == nil {
+		return w.walkDir(dir, rel, depth, rules)
+	}
+
+	id := fileID{dev: info.Device, ino: info.Inode}
+	if w.visiting[id] {
+		return w.yield(types.FileInfo{}, fmt.Errorf("symlink loop at %s", dir))
+	}
+	w.visiting[id] = true
+	defer delete(w.visiting, id)
+	return w.walkDir(dir, rel, depth, rules)
+}
+
+// stat reads the information of the entries of a directory, concurrently
+// if requested by the options.
+func (w *walker) stat(dir string, entries []walkEntry) {
+	read := func(entry *walkEntry) {
+		path := filepath.Join(dir, entry.name)
+		info, err := os.Lstat(path)
+		follow := false
+		if err == nil && info.Mode()&fs.ModeSymlink != 0 && w.options.Symlinks == types.SymlinksFollow {
+			if target, err := os.Stat(path); err == nil {
+				info, follow = target, true
+			}
+		}
+		if err != nil {
+			entry.err = err
+			return
+		}
+		entry.info, entry.err = w.reader.read(path, info, follow)
+	}
+
+	workers := min(w.options.Parallel, len(entries))
+	if workers <= 1 {
+		for i := range entries {
+			read(&entries[i])
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				read(&entries[i])
+			}
+		}()
+	}
+	for i := range entries {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
+// excluded reports whether an entry matches the Exclude patterns.
+func (w *walker) excluded(name, rel string) bool {
+	slashRel := filepath.ToSlash(rel)
+	if matchesPattern(name, slashRel, w.options.Exclude) {
+		return true
+	}
+	return w.options.ExcludeRegexp != nil && w.options.ExcludeRegexp.MatchString(slashRel)
+}
+
+// included reports whether a file matches the Include patterns.
+func (w *walker) included(name, rel string) bool {
+	slashRel := filepath.ToSlash(rel)
+	if len(w.options.Include) > 0 && !matchesPattern(name, slashRel, w.options.Include) {
+		return false
+	}
+	return w.options.IncludeRegexp == nil || w.options.IncludeRegexp.MatchString(slashRel)
+}
+
+// readIgnoreFiles appends the rules of the ignore files of a directory to
+// the rules of its parents.
+func (w *walker) readIgnoreFiles(dir, rel string, rules []ignoreRule) ([]ignoreRule, error) {
+	if len(w.options.IgnoreFiles) == 0 {
+		return rules, nil
+	}
+
+	// the parents share the beginning of the slice, so never append to it
+	rules = rules[:len(rules):len(rules)]
+	for _, name := range w.options.IgnoreFiles {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return rules, err
+		}
+		rules = append(rules, parseIgnoreFile(string(data), filepath.ToSlash(rel))...)
+	}
+	return rules, nil
+}
+
+// ignoreRule is a pattern of an ignore file.
+type ignoreRule struct {
+	// base is the slash separated path of the directory of the ignore
+	// file, relative to the root
+	base    string
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// parseIgnoreFile parses the content of a .gitignore-style file, skipping
+// the invalid patterns as git does.
+func parseIgnoreFile(content, base string) []ignoreRule {
+	var rules []ignoreRule
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if !strings.HasSuffix(line, `\ `) {
+			line = strings.TrimRight(line, " ")
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		re, err := regexp.Compile(ignorePattern(line))
+		if err != nil || line == "" {
+			continue
+		}
+		rule.re = re
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// ignorePattern converts a .gitignore pattern into a regular expression
+// matching the slash separated paths relative to the ignore file.
+func ignorePattern(pattern string) string {
+	var b strings.Builder
+	b.WriteString("^")
+
+	// patterns without a slash, except a trailing one, match at any depth
+	if !strings.Contains(pattern, "/") {
+		b.WriteString("(?:.*/)?")
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	for i := 0; i < len(pattern); i++ {
+		rest := pattern[i:]
+		switch {
+		case strings.HasPrefix(rest, "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case rest == "/**":
+			b.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(rest, "**"):
+			b.WriteString(".*")
+			i++
+		case rest[0] == '*':
+			b.WriteString("[^/]*")
+		case rest[0] == '?':
+			b.WriteString("[^/]")
+		case rest[0] == '[' && strings.IndexByte(rest[1:], ']') > 0:
+			end := strings.IndexByte(rest[1:], ']') + 1
+			class := rest[1:end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end
+		case rest[0] == '\\' && len(rest) > 1:
+			b.WriteString(regexp.QuoteMeta(rest[1:2]))
+			i++
+		default:
+			b.WriteString(regexp.QuoteMeta(rest[:1]))
+		}
+	}
+
+	b.WriteString("$")
+	return b.String()
+}
+
+// ignored reports whether a path, slash separated and relative to the
+// root, is ignored by the rules. The last matching rule wins.
+func ignored(rules []ignoreRule, rel string, isDir bool) bool {
+	result := false
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		path := rel
+		if rule.base != "" {
+			path = strings.TrimPrefix(rel, rule.base+"/")
+		}
+		if rule.re.MatchString(path) {
+			result = !rule.negate
+		}
+	}
+	return result
+}
@@ -0,0 +1,546 @@
+package fs
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: File change notifications based on inotify.
+*/
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/vanilla-os/sdk/pkg/v1/fs/types"
+	"golang.org/x/sys/unix"
+)
+
+// ErrWatchOverflow is sent on the Errors channel of a watcher when the
+// kernel dropped some events because they were not read fast enough.
+var ErrWatchOverflow = errors.New("watch event queue overflowed, some events were lost")
+
+const (
+	// watchMask is the set of inotify events requested for each directory
+	watchMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MODIFY | unix.IN_ATTRIB |
+		unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_DELETE_SELF | unix.IN_MOVE_SELF |
+		unix.IN_ONLYDIR | unix.IN_DONT_FOLLOW
+
+	// moveTimeout is how long the first half of a rename waits for the
+	// second one before being reported as a removal
+	moveTimeout = 50 * time.Millisecond
+
+	// watchBufferSize is the size of the Events channel
+	watchBufferSize = 64
+)
+
+// Watcher reports the changes of a file or of a directory tree, see Watch.
+type Watcher struct {
+	root    string
+	file    string
+	options types.WatchOptions
+
+	inotify *os.File
+	fd      int
+	watches map[int]string
+	paths   map[string]int
+
+	pending  map[string]*types.WatchEvent
+	order    []string
+	first    time.Time
+	last     time.Time
+	moves    map[uint32]pendingMove
+	timer    *time.Timer
+	events   chan types.WatchEvent
+	errors   chan error
+	cancel   context.CancelFunc
+	finished chan struct{}
+}
+
+// pendingMove is the first half of a rename, waiting for the second one.
+type pendingMove struct {
+	path  string
+	isDir bool
+	at    time.Time
+}
+
+// inotifyEvent is an event read from inotify.
+type inotifyEvent struct {
+	wd     int
+	mask   uint32
+	cookie uint32
+	name   string
+}
+
+// Watch starts watching a file or a directory for changes until the
+// context is canceled or the watcher is closed. The events are delivered
+// on the Events channel, or to the EventManager of the options.
+//
+// A file is watched through its directory, so that replacing it, as
+// AtomicWriteFile does, is reported as a creation. Renames within the
+// watched tree are reported as a single WatchRename event, while files
+// moved out of or into it are reported as removed or created.
+//
+// Example:
+//
+//	watcher, err := fs.Watch(ctx, "/etc/myapp", types.WatchOptions{
+//		Recursive: true,
+//		Debounce:  200 * time.Millisecond,
+//	})
+//	if err != nil {
+//		fmt.Printf("Error watching directory: %v", err)
+//		return
+//	}
+//	for event := range watcher.Events() {
+//		fmt.Printf("%s: %s\n", event.Op, event.Path)
+//	}
+func Watch(ctx context.Context, path string, options types.WatchOptions) (*Watcher, error) {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+
+	w := &Watcher{
+		root:     root,
+		options:  options,
+		inotify:  os.NewFile(uintptr(fd), "inotify"),
+		fd:       fd,
+		watches:  make(map[int]string),
+		paths:    make(map[string]int),
+		pending:  make(map[string]*types.WatchEvent),
+		moves:    make(map[uint32]pendingMove),
+		events:   make(chan types.WatchEvent, watchBufferSize),
+		errors:   make(chan error, 1),
+		finished: make(chan struct{}),
+	}
+	if w.options.EventName == "" {
+		w.options.EventName = types.WatchEventName
+	}
+
+	switch {
+	case !info.IsDir():
+		w.file = filepath.Base(root)
+		err = w.addWatch(filepath.Dir(root))
+	case options.Recursive:
+		err = w.watchTree(root, false)
+	default:
+		err = w.addWatch(root)
+	}
+	if err != nil {
+		w.inotify.Close()
+		return nil, err
+	}
+
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.timer = time.NewTimer(time.Hour)
+	w.timer.Stop()
+
+	batches := make(chan []inotifyEvent)
+	go w.read(ctx, batches)
+	go w.run(ctx, batches)
+	return w, nil
+}
+
+// Events returns the channel of the changes, closed when the watcher
+// stops. Nothing is sent on it when the events are delivered to an
+// EventManager.
+func (w *Watcher) Events() <-chan types.WatchEvent {
+	return w.events
+}
+
+// Errors returns the channel of the errors happened while watching, e.g.
+// ErrWatchOverflow, closed when the watcher stops. Errors are dropped if
+// the previous one was not received yet.
+func (w *Watcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Close stops the watcher and waits for it to release its resources.
+//
+// Example:
+//
+//	defer watcher.Close()
+func (w *Watcher) Close() error {
+	w.cancel()
+	<-w.finished
+	return nil
+}
+
+// read reads the inotify events and sends them in batches to run, until
+// the inotify file is closed.
+func (w *Watcher) read(ctx context.Context, batches chan<- []inotifyEvent) {
+	defer close(batches)
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := w.inotify.Read(buf)
+		if err != nil {
+			if !errors.Is(err, os.ErrClosed) {
+				w.sendError(err)
+			}
+			return
+		}
+
+		var batch []inotifyEvent
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			raw := buf[offset:]
+			event := inotifyEvent{
+				wd:     int(int32(binary.NativeEndian.Uint32(raw[0:4]))),
+				mask:   binary.NativeEndian.Uint32(raw[4:8]),
+				cookie: binary.NativeEndian.Uint32(raw[8:12]),
+			}
+			length := int(binary.NativeEndian.Uint32(raw[12:16]))
+			name := raw[unix.SizeofInotifyEvent : unix.SizeofInotifyEvent+length]
+			event.name = strings.TrimRight(string(name), "\x00")
+			batch = append(batch, event)
+			offset += unix.SizeofInotifyEvent + length
+		}
+
+		select {
+		case batches <- batch:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run handles the inotify events and delivers the changes, until the
+// context is canceled.
+func (w *Watcher) run(ctx context.Context, batches <-chan []inotifyEvent) {
+	defer func() {
+		w.timer.Stop()
+		w.inotify.Close()
+		for range batches {
+		}
+		close(w.events)
+		close(w.errors)
+		close(w.finished)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case batch, ok := <-batches:
+			if !ok {
+				return
+			}
+			for _, event := range batch {
+				w.handle(event)
+			}
+		case <-w.timer.C:
+		}
+
+		if !w.deliver(ctx) {
+			return
+		}
+	}
+}
+
+// handle turns an inotify event into a pending change.
+func (w *Watcher) handle(event inotifyEvent) {
+	if event.mask&unix.IN_Q_OVERFLOW != 0 {
+		w.sendError(ErrWatchOverflow)
+		return
+	}
+
+	dir, ok := w.watches[event.wd]
+	if !ok {
+		return
+	}
+	if event.mask&unix.IN_IGNORED != 0 {
+		delete(w.watches, event.wd)
+		if w.paths[dir] == event.wd {
+			delete(w.paths, dir)
+		}
+		return
+	}
+
+	if event.name == "" {
+		// changes of the watched directories themselves are reported by
+		// their parent, except for the root
+		if dir == w.root && w.file == "" && event.mask&(unix.IN_DELETE_SELF|unix.IN_MOVE_SELF) != 0 {
+			w.queue(dir, types.WatchRemove, true)
+			w.unwatchTree(dir)
+		}
+		return
+	}
+	if w.file != "" && event.name != w.file {
+		return
+	}
+
+	path := filepath.Join(dir, event.name)
+	isDir := event.mask&unix.IN_ISDIR != 0
+	if w.excluded(path) {
+		return
+	}
+
+	switch {
+	case event.mask&unix.IN_CREATE != 0:
+		w.queue(path, types.WatchCreate, isDir)
+		if isDir && w.options.Recursive {
+			w.watchNew(path)
+		}
+	case event.mask&unix.IN_MOVED_FROM != 0:
+		w.moves[event.cookie] = pendingMove{path: path, isDir: isDir, at: time.Now()}
+	case event.mask&unix.IN_MOVED_TO != 0:
+		if from, ok := w.moves[event.cookie]; ok {
+			delete(w.moves, event.cookie)
+			w.rename(from.path, path, isDir)
+			return
+		}
+		w.queue(path, types.WatchCreate, isDir)
+		if isDir && w.options.Recursive {
+			w.watchNew(path)
+		}
+	case event.mask&unix.IN_DELETE != 0:
+		w.queue(path, types.WatchRemove, isDir)
+	case event.mask&unix.IN_MODIFY != 0:
+		w.queue(path, types.WatchWrite, isDir)
+	case event.mask&unix.IN_ATTRIB != 0:
+		w.queue(path, types.WatchChmod, isDir)
+	}
+}
+
+// queue adds a change to the pending ones, coalescing it with the previous
+// change of the same file.
+func (w *Watcher) queue(path string, op types.WatchOp, isDir bool) {
+	now := time.Now()
+	if len(w.pending) == 0 {
+		w.first = now
+	}
+	w.last = now
+
+	event, ok := w.pending[path]
+	if !ok {
+		w.pending[path] = &types.WatchEvent{Path: path, Op: op, IsDirectory: isDir}
+		w.order = append(w.order, path)
+		return
+	}
+
+	event.IsDirectory = isDir
+	switch {
+	case op == types.WatchRemove && event.Op.Has(types.WatchRename):
+		// the renamed file is gone, the old path is what was removed
+		delete(w.pending, path)
+		w.queue(event.OldPath, types.WatchRemove, isDir)
+	case op == types.WatchRemove && event.Op.Has(types.WatchCreate):
+		// created and removed before being reported
+		delete(w.pending, path)
+	case op == types.WatchRemove:
+		event.Op = types.WatchRemove
+	case op == types.WatchCreate && event.Op.Has(types.WatchRemove):
+		// removed and created again, i.e. replaced
+		event.Op = types.WatchCreate
+	default:
+		event.Op |= op
+	}
+}
+
+// rename records a file renamed within the watched tree.
+func (w *Watcher) rename(oldPath, newPath string, isDir bool) {
+	if isDir {
+		w.relocate(oldPath, newPath)
+	}
+
+	previous, ok := w.pending[oldPath]
+	delete(w.pending, oldPath)
+	delete(w.pending, newPath)
+	switch {
+	case ok && previous.Op.Has(types.WatchCreate):
+		// created and renamed before being reported
+		w.queue(newPath, types.WatchCreate, isDir)
+		return
+	case ok && previous.Op.Has(types.WatchRename):
+		oldPath = previous.OldPath
+	}
+
+	w.queue(newPath, types.WatchRename, isDir)
+	w.pending[newPath].OldPath = oldPath
+}
+
+// deliver reports the pending changes that are due and arms the timer for
+// the others. It returns false if the context was canceled meanwhile.
+func (w *Watcher) deliver(ctx context.Context) bool {
+	now := time.Now()
+	var wake time.Time
+	for cookie, move := range w.moves {
+		if due := move.at.Add(moveTimeout); now.Before(due) {
+			wake = earliest(wake, due)
+			continue
+		}
+		delete(w.moves, cookie)
+		w.queue(move.path, types.WatchRemove, move.isDir)
+		if move.isDir {
+			w.unwatchTree(move.path)
+		}
+	}
+
+	if len(w.pending) > 0 && w.options.Debounce > 0 {
+		due := w.last.Add(w.options.Debounce)
+		if limit := w.first.Add(10 * w.options.Debounce); limit.Before(due) {
+			due = limit
+		}
+		if now.Before(due) {
+			wake = earliest(wake, due)
+		} else if !w.flush(ctx) {
+			return false
+		}
+	} else if !w.flush(ctx) {
+		return false
+	}
+
+	if !wake.IsZero() {
+		w.timer.Reset(time.Until(wake))
+	}
+	return true
+}
+
+// flush delivers all the pending changes in the order they happened.
+func (w *Watcher) flush(ctx context.Context) bool {
+	order := w.order
+	w.order = nil
+	for _, path := range order {
+		event, ok := w.pending[path]
+		if !ok {
+			continue
+		}
+		delete(w.pending, path)
+
+		if w.options.EventManager != nil {
+			w.options.EventManager.Notify(w.options.EventName, *event)
+			continue
+		}
+		select {
+		case w.events <- *event:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// earliest returns the earliest of two times, ignoring a zero one.
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || b.Before(a) {
+		return b
+	}
+	return a
+}
+
+// excluded reports whether a path matches the Exclude patterns.
+func (w *Watcher) excluded(path string) bool {
+	if len(w.options.Exclude) == 0 {
+		return false
+	}
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil {
+		return false
+	}
+	return matchesPattern(filepath.Base(path), filepath.ToSlash(rel), w.options.Exclude)
+}
+
+// addWatch starts watching a directory.
+func (w *Watcher) addWatch(dir string) error {
+	wd, err := unix.InotifyAddWatch(w.fd, dir, watchMask)
+	if err != nil {
+		if errors.Is(err, unix.ENOSPC) {
+			return fmt.Errorf("cannot watch %s, the inotify watch limit was reached: %w", dir, err)
+		}
+		return &os.PathError{Op: "inotify_add_watch", Path: dir, Err: err}
+	}
+	w.watches[wd] = dir
+	w.paths[dir] = wd
+	return nil
+}
+
+// watchTree watches a directory and its subdirectories, except the
+// excluded and unreadable ones. If report is true, the files found are
+// queued as created, since their creation could not be seen.
+func (w *Watcher) watchTree(root string, report bool) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path != root && (errors.Is(err, fs.ErrPermission) || errors.Is(err, fs.ErrNotExist)) {
+				return nil
+			}
+			return err
+		}
+		if path != root && w.excluded(path) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if report && path != root {
+			w.queue(path, types.WatchCreate, d.IsDir())
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := w.addWatch(path); err != nil {
+			if path != root && (errors.Is(err, unix.EACCES) || errors.Is(err, unix.ENOENT)) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// watchNew watches a directory created or moved into the watched tree.
+func (w *Watcher) watchNew(dir string) {
+	if err := w.watchTree(dir, true); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		w.sendError(err)
+	}
+}
+
+// unwatchTree stops watching a directory and its subdirectories.
+func (w *Watcher) unwatchTree(dir string) {
+	for path, wd := range w.paths {
+		if isSubPath(dir, path) {
+			unix.InotifyRmWatch(w.fd, uint32(wd))
+			delete(w.paths, path)
+			delete(w.watches, wd)
+		}
+	}
+}
+
+// relocate updates the paths of the watched directories after a directory
+// was renamed.
+func (w *Watcher) relocate(oldDir, newDir string) {
+	moved := make(map[string]int)
+	for path, wd := range w.paths {
+		if isSubPath(oldDir, path) {
+			moved[newDir+strings.TrimPrefix(path, oldDir)] = wd
+			delete(w.paths, path)
+		}
+	}
+	for path, wd := range moved {
+		w.paths[path] = wd
+		w.watches[wd] = path
+	}
+}
+
+// sendError sends an error without blocking.
+func (w *Watcher) sendError(err error) {
+	select {
+	case w.errors <- err:
+	default:
+	}
+}
@@ -0,0 +1,100 @@
+package fs
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// readXattrs returns the extended attributes of a file, following the
+// symlinks only if follow is true. Filesystems without extended attributes
+// result in an empty map.
+func readXattrs(path string, follow bool) (map[string][]byte, error) {
+	list, get := unix.Llistxattr, unix.Lgetxattr
+	if follow {
+		list, get = unix.Listxattr, unix.Getxattr
+	}
+
+	names, err := readXattrBuffer(func(dest []byte) (int, error) {
+		return list(path, dest)
+	})
+	if errors.Is(err, unix.ENOTSUP) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := make(map[string][]byte)
+	for _, name := range bytes.Split(names, []byte{0}) {
+		if len(name) == 0 {
+			continue
+		}
+		value, err := readXattrBuffer(func(dest []byte) (int, error) {
+			return get(path, string(name), dest)
+		})
+		if errors.Is(err, unix.ENODATA) {
+			continue // removed in the meantime
+		}
+		if err != nil {
+			return nil, err
+		}
+		attrs[string(name)] = value
+	}
+	return attrs, nil
+}
+
+// readXattrBuffer calls a listxattr or getxattr like function with a
+// buffer large enough for the result, retrying if it grows in between.
+func readXattrBuffer(read func(dest []byte) (int, error)) ([]byte, error) {
+	for {
+		size, err := read(nil)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return nil, nil
+		}
+		buf := make([]byte, size)
+		n, err := read(buf)
+		if errors.Is(err, unix.ERANGE) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+}
+
+// writeXattrs sets the extended attributes of a file, following the
+// symlinks only if follow is true. The attributes the filesystem or the
+// user cannot set, e.g. the trusted ones when not running as root, are
+// skipped.
+func writeXattrs(path string, attrs map[string][]byte, follow bool) error {
+	set := unix.Lsetxattr
+	if follow {
+		set = unix.Setxattr
+	}
+
+	for name, value := range attrs {
+		err := set(path, name, value, 0)
+		if errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EPERM) || errors.Is(err, unix.EACCES) {
+			continue
+		}
+		if err != nil {
+			return &os.PathError{Op: "setxattr", Path: path, Err: err}
+		}
+	}
+	return nil
+}
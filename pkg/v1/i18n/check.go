@@ -9,17 +9,34 @@ package i18n
 */
 
 import (
-	"bufio"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
 	"io/fs"
-	"os"
+	"math"
 	"path/filepath"
-	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/vanilla-os/sdk/pkg/v1/i18n/types"
+)
+
+const (
+	// CheckReportJSONEnv is the environment variable giving the path of the
+	// JSON report written by the applications built with the
+	// check_missing_strings tag; no report is written if not set
+	CheckReportJSONEnv = "VANILLA_I18N_REPORT_JSON"
+
+	// CheckReportJUnitEnv is the environment variable giving the path of
+	// the JUnit report written by the applications built with the
+	// check_missing_strings tag; no report is written if not set
+	CheckReportJUnitEnv = "VANILLA_I18N_REPORT_JUNIT"
 )
 
-// CheckMissingStrings scans the rootPath for translation calls (e.g., .Trans("KEY"))
-// and verifies they exist in the provided locale file (PO format).
+// CheckMissingStrings scans the rootPath for translatable strings (Trans
+// and NTrans calls and "pr:" keys) and verifies they exist in the provided
+// locale file (PO format).
 //
 // It returns a map where the key is the file path and the value is a slice of missing keys.
 //
@@ -33,81 +50,256 @@ import (
 //		fmt.Printf("File %s has missing keys: %v\n", file, keys)
 //	}
 func CheckMissingStrings(rootPath string, localeFile string) (map[string][]string, error) {
-	localeData, err := os.Open(localeFile)
+	catalog, err := ReadPO(localeFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open locale file: %w", err)
+		return nil, fmt.Errorf("failed to read locale file: %w", err)
+	}
+	template, err := Extract(rootPath, types.ExtractOptions{})
+	if err != nil {
+		return nil, err
 	}
-	defer localeData.Close()
-
-	definedKeys := make(map[string]bool)
-	scanner := bufio.NewScanner(localeData)
-
-	msgidRe := regexp.MustCompile(`^msgid "(.+)"$`)
 
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if strings.HasPrefix(line, "msgid \"") {
-			matches := msgidRe.FindStringSubmatch(line)
-			if len(matches) > 1 {
-				definedKeys[matches[1]] = true
+	missing := make(map[string][]string)
+	report := CheckCatalog(template, "", localeFile, catalog)
+	for _, entry := range report.Missing {
+		for _, ref := range entry.References {
+			file, _, _ := strings.Cut(ref, ":")
+			path := filepath.Join(rootPath, filepath.FromSlash(file))
+			if !slices.Contains(missing[path], entry.ID) {
+				missing[path] = append(missing[path], entry.ID)
 			}
 		}
 	}
+	return missing, nil
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading locale file: %w", err)
+// CheckLocales extracts the translatable strings of the source code in
+// rootPath and checks them against every catalog found in localesDir,
+// either laid out as <lang>/LC_MESSAGES/<domain>.po, <lang>/<domain>.po or
+// <lang>.po. The report holds, per locale, the coverage and the missing,
+// untranslated, fuzzy and unused strings.
+//
+// Example:
+//
+//	report, err := i18n.CheckLocales(".", "locales", types.ExtractOptions{})
+//	if err != nil {
+//		return err
+//	}
+//	for _, locale := range report.Locales {
+//		fmt.Printf("%s: %.1f%%\n", locale.Locale, locale.Coverage)
+//	}
+func CheckLocales(rootPath, localesDir string, options types.ExtractOptions) (*types.CheckReport, error) {
+	template, err := Extract(rootPath, options)
+	if err != nil {
+		return nil, err
 	}
 
-	sourceRe := regexp.MustCompile(`\.Trans\("([\S.]+)"`)
-	prRe := regexp.MustCompile(`pr:([\w\.]+)`)
-	missing := make(map[string][]string)
-
-	err = filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+	report := &types.CheckReport{Strings: len(template.Messages)}
+	err = filepath.WalkDir(localesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".po" {
+			return err
+		}
+		catalog, err := ReadPO(path)
 		if err != nil {
 			return err
 		}
-		if d.IsDir() {
-			if d.Name() == ".git" || d.Name() == "vendor" {
-				return filepath.SkipDir
-			}
-			return nil
+		rel, err := filepath.Rel(localesDir, path)
+		if err != nil {
+			return err
 		}
-		if !strings.HasSuffix(d.Name(), ".go") {
-			return nil
+		report.Locales = append(report.Locales, CheckCatalog(template, catalogLocale(rel), path, catalog))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(report.Locales, func(a, b types.LocaleReport) int {
+		return strings.Compare(a.Locale, b.Locale)
+	})
+	return report, nil
+}
+
+// catalogLocale returns the locale of a catalog from its path relative to
+// the locales directory.
+func catalogLocale(rel string) string {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if i := slices.Index(parts, "LC_MESSAGES"); i > 0 {
+		return parts[i-1]
+	}
+	if len(parts) > 1 {
+		return parts[len(parts)-2]
+	}
+	return strings.TrimSuffix(parts[0], ".po")
+}
+
+// CheckCatalog checks a catalog against a template, usually returned by
+// Extract.
+//
+// Example:
+//
+//	italian, err := i18n.ReadPO("locales/it/LC_MESSAGES/batsignal.po")
+//	if err != nil {
+//		return err
+//	}
+//	report := i18n.CheckCatalog(template, "it", "locales/it/LC_MESSAGES/batsignal.po", italian)
+//	fmt.Printf("%d strings to translate\n", len(report.Untranslated))
+func CheckCatalog(template *types.Catalog, locale, file string, catalog *types.Catalog) types.LocaleReport {
+	report := types.LocaleReport{
+		Locale:       locale,
+		File:         file,
+		Total:        len(template.Messages),
+		Missing:      []types.CheckEntry{},
+		Untranslated: []types.CheckEntry{},
+		Fuzzy:        []types.CheckEntry{},
+		Unused:       []types.CheckEntry{},
+	}
+
+	used := make(map[string]bool)
+	for _, tmsg := range template.Messages {
+		used[tmsg.Key()] = true
+		entry := types.CheckEntry{Context: tmsg.Context, ID: tmsg.ID, References: tmsg.References}
+
+		msg := catalog.Find(tmsg.Context, tmsg.ID)
+		switch {
+		case msg == nil || msg.Obsolete:
+			report.Missing = append(report.Missing, entry)
+		case msg.Fuzzy():
+			report.Fuzzy = append(report.Fuzzy, entry)
+		case !msg.Translated():
+			report.Untranslated = append(report.Untranslated, entry)
+		default:
+			report.Translated++
 		}
+	}
 
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return nil
+	for _, msg := range catalog.Messages {
+		if !msg.Obsolete && !used[msg.Key()] {
+			report.Unused = append(report.Unused, types.CheckEntry{Context: msg.Context, ID: msg.ID, References: msg.References})
 		}
+	}
 
-		matches := sourceRe.FindAllStringSubmatch(string(content), -1)
-		matches = append(matches, prRe.FindAllStringSubmatch(string(content), -1)...)
-		for _, match := range matches {
-			if len(match) < 2 {
-				continue
-			}
-			key := match[1]
-			if !definedKeys[key] {
-				seen := false
-				for _, k := range missing[path] {
-					if k == key {
-						seen = true
-						break
-					}
+	report.Coverage = 100
+	if report.Total > 0 {
+		report.Coverage = math.Round(float64(report.Translated)/float64(report.Total)*1000) / 10
+	}
+	return report
+}
+
+// WriteCheckJSON writes a check report as indented JSON.
+//
+// Example:
+//
+//	f, err := os.Create("i18n-report.json")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	err = i18n.WriteCheckJSON(f, report)
+func WriteCheckJSON(w io.Writer, report *types.CheckReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// junitTestSuites is the root element of a JUnit report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the test cases of a locale.
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a string of a locale.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+// junitMessage is the failure or skip reason of a test case.
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteCheckJUnit writes a check report in the JUnit XML format understood
+// by most CI systems: each locale is a test suite, missing strings are
+// failures, while untranslated, fuzzy and unused strings are reported as
+// skipped test cases.
+//
+// Example:
+//
+//	f, err := os.Create("i18n-report.xml")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	err = i18n.WriteCheckJUnit(f, report)
+func WriteCheckJUnit(w io.Writer, report *types.CheckReport) error {
+	suites := junitTestSuites{Name: "i18n"}
+	for _, locale := range report.Locales {
+		suite := junitTestSuite{Name: locale.Locale}
+		addCases := func(entries []types.CheckEntry, kind string, failure bool) {
+			for _, entry := range entries {
+				message := &junitMessage{
+					Message: fmt.Sprintf("%s string in %s", kind, locale.File),
+					Type:    kind,
+					Text:    strings.Join(entry.References, "\n"),
+				}
+				tc := junitTestCase{Name: entry.ID, ClassName: "i18n." + locale.Locale}
+				if entry.Context != "" {
+					tc.Name = entry.Context + "|" + entry.ID
 				}
-				if !seen {
-					missing[path] = append(missing[path], key)
+				if failure {
+					tc.Failure = message
+					suite.Failures++
+				} else {
+					tc.Skipped = message
+					suite.Skipped++
 				}
+				suite.Cases = append(suite.Cases, tc)
 			}
 		}
+		addCases(locale.Missing, "missing", true)
+		addCases(locale.Untranslated, "untranslated", false)
+		addCases(locale.Fuzzy, "fuzzy", false)
+		addCases(locale.Unused, "unused", false)
 
-		return nil
-	})
+		// translated strings are reported as a single passing test case
+		if locale.Translated > 0 {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      fmt.Sprintf("%d translated strings (%.1f%%)", locale.Translated, locale.Coverage),
+				ClassName: "i18n." + locale.Locale,
+			})
+		}
+		suite.Tests = len(suite.Cases)
 
-	if err != nil {
-		return nil, err
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Suites = append(suites.Suites, suite)
 	}
 
-	return missing, nil
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
 }
@@ -0,0 +1,338 @@
+package i18n
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Extraction of translatable strings from Go source code.
+*/
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vanilla-os/sdk/pkg/v1/i18n/types"
+)
+
+// translatorCommentPrefix marks the comments meant for translators, they
+// are copied into the template when placed right before a string.
+const translatorCommentPrefix = "TRANSLATORS:"
+
+// goFormatRe matches the Go formatting verbs, used to flag the strings
+// passed to the fmt functions.
+var goFormatRe = regexp.MustCompile(`%[-+# 0]*(\d+|\*)?(\.(\d+|\*))?[vTtbcdoOqxXUeEfFgGsp]`)
+
+// Extract scans the Go files under rootPath and returns a template catalog
+// (POT) with the strings passed to Trans and NTrans calls and the "pr:"
+// keys found in string literals and struct tags, e.g. the help of the
+// commands. Each entry carries its source references, the plural form for
+// NTrans and the "TRANSLATORS:" comments placed right before the string.
+//
+// Example:
+//
+//	template, err := i18n.Extract(".", types.ExtractOptions{
+//		Package: "batsignal",
+//		Version: "1.0.0",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	err = i18n.WritePOFile("locales/batsignal.pot", template)
+func Extract(rootPath string, options types.ExtractOptions) (*types.Catalog, error) {
+	e := extractor{
+		catalog: newTemplate(options),
+		index:   make(map[string]*types.Message),
+	}
+
+	err := filepath.WalkDir(rootPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(rootPath, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel != "." && skipExtractDir(d.Name(), rel, options.Exclude) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".go") || strings.HasSuffix(d.Name(), "_test.go") ||
+			matchesExclude(d.Name(), rel, options.Exclude) {
+			return nil
+		}
+		return e.extractFile(p, rel)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e.catalog, nil
+}
+
+// skipExtractDir reports whether a directory must not be scanned.
+func skipExtractDir(name, rel string, exclude []string) bool {
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata" {
+		return true
+	}
+	return matchesExclude(name, rel, exclude)
+}
+
+// matchesExclude reports whether a file or directory matches one of the
+// exclude patterns, by name or by relative path.
+func matchesExclude(name, rel string, exclude []string) bool {
+	for _, pattern := range exclude {
+		if pattern == name || pattern == rel {
+			return true
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// newTemplate returns an empty template with the standard header.
+func newTemplate(options types.ExtractOptions) *types.Catalog {
+	name := options.Package
+	if name == "" {
+		name = "PACKAGE"
+	}
+	project := name
+	if options.Version != "" {
+		project += " " + options.Version
+	}
+
+	catalog := &types.Catalog{
+		Header: &types.Message{
+			Comments: []string{"SOME DESCRIPTIVE TITLE.", "This file is distributed under the same license as the " + name + " package."},
+			Flags:    []string{"fuzzy"},
+		},
+	}
+	for _, field := range [][2]string{
+		{"Project-Id-Version", project},
+		{"Report-Msgid-Bugs-To", options.BugsAddress},
+		{"POT-Creation-Date", catalogDate().Format("2006-01-02 15:04-0700")},
+		{"PO-Revision-Date", "YEAR-MO-DA HO:MI+ZONE"},
+		{"Last-Translator", "FULL NAME <EMAIL@ADDRESS>"},
+		{"Language-Team", "LANGUAGE <LL@li.org>"},
+		{"Language", ""},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "8bit"},
+		{"Plural-Forms", "nplurals=INTEGER; plural=EXPRESSION;"},
+	} {
+		catalog.SetHeaderField(field[0], field[1])
+	}
+	return catalog
+}
+
+// catalogDate returns the creation date of the catalogs, honoring
+// SOURCE_DATE_EPOCH for reproducible builds.
+func catalogDate() time.Time {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		if seconds, err := strconv.ParseInt(epoch, 10, 64); err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+	}
+	return time.Now()
+}
+
+// extractor collects the strings of the scanned files.
+type extractor struct {
+	catalog *types.Catalog
+	index   map[string]*types.Message
+
+	fset     *token.FileSet
+	rel      string
+	comments map[int]string
+	seen     map[ast.Node]bool
+}
+
+// extractFile collects the strings of a single file.
+func (e *extractor) extractFile(p, rel string) error {
+	e.fset = token.NewFileSet()
+	file, err := parser.ParseFile(e.fset, p, nil, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("cannot parse %s: %w", rel, err)
+	}
+	e.rel = rel
+	e.seen = make(map[ast.Node]bool)
+
+	// translator comments are indexed by the line they end on
+	e.comments = make(map[int]string)
+	for _, group := range file.Comments {
+		text := strings.TrimSpace(group.Text())
+		if strings.HasPrefix(strings.ToUpper(text), translatorCommentPrefix) {
+			text = strings.TrimSpace(text[len(translatorCommentPrefix):])
+			e.comments[e.fset.Position(group.End()).Line] = strings.Join(strings.Fields(text), " ")
+		}
+	}
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			e.extractCall(n)
+		case *ast.Field:
+			if n.Tag != nil {
+				e.seen[n.Tag] = true
+				e.extractTag(n.Tag)
+			}
+		case *ast.BasicLit:
+			if n.Kind != token.STRING || e.seen[n] {
+				return true
+			}
+			if value, err := strconv.Unquote(n.Value); err == nil && strings.HasPrefix(value, "pr:") {
+				e.add(n.Pos(), value[len("pr:"):], "")
+			}
+		}
+		return true
+	})
+	return nil
+}
+
+// extractCall collects the strings passed to Trans and NTrans.
+func (e *extractor) extractCall(call *ast.CallExpr) {
+	var name string
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		name = fun.Sel.Name
+	case *ast.Ident:
+		name = fun.Name
+	}
+
+	switch {
+	case name == "Trans" && len(call.Args) >= 1:
+		if id, ok := e.stringValue(call.Args[0]); ok {
+			e.add(call.Args[0].Pos(), cleanKey(id), "")
+		}
+	case name == "NTrans" && len(call.Args) >= 2:
+		id, ok := e.stringValue(call.Args[0])
+		plural, pluralOk := e.stringValue(call.Args[1])
+		if ok && pluralOk {
+			e.add(call.Args[0].Pos(), cleanKey(id), plural)
+		}
+	}
+}
+
+// extractTag collects the "pr:" keys used as values of a struct tag.
+func (e *extractor) extractTag(tag *ast.BasicLit) {
+	value, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return
+	}
+	for _, v := range tagValues(value) {
+		if strings.HasPrefix(v, "pr:") {
+			e.add(tag.Pos(), v[len("pr:"):], "")
+		}
+	}
+}
+
+// tagValues returns the values of a struct tag, parsed as reflect does.
+func tagValues(tag string) []string {
+	var values []string
+	for tag != "" {
+		tag = strings.TrimLeft(tag, " ")
+		i := strings.Index(tag, `:"`)
+		if i <= 0 {
+			break
+		}
+		tag = tag[i+1:]
+
+		// scan to the closing quote, skipping escaped characters
+		j := 1
+		for j < len(tag) && tag[j] != '"' {
+			if tag[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		if j >= len(tag) {
+			break
+		}
+		if value, err := strconv.Unquote(tag[:j+1]); err == nil {
+			values = append(values, value)
+		}
+		tag = tag[j+1:]
+	}
+	return values
+}
+
+// stringValue returns the value of a string literal or of a concatenation
+// of string literals, marking them as seen.
+func (e *extractor) stringValue(expr ast.Expr) (string, bool) {
+	switch x := expr.(type) {
+	case *ast.BasicLit:
+		if x.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(x.Value)
+		if err != nil {
+			return "", false
+		}
+		e.seen[x] = true
+		return value, true
+	case *ast.ParenExpr:
+		return e.stringValue(x.X)
+	case *ast.BinaryExpr:
+		if x.Op != token.ADD {
+			return "", false
+		}
+		left, ok := e.stringValue(x.X)
+		if !ok {
+			return "", false
+		}
+		right, ok := e.stringValue(x.Y)
+		return left + right, ok
+	}
+	return "", false
+}
+
+// add adds a string to the template, merging the references of duplicates.
+func (e *extractor) add(pos token.Pos, id, plural string) {
+	if id == "" {
+		return
+	}
+	line := e.fset.Position(pos).Line
+	ref := e.rel + ":" + strconv.Itoa(line)
+
+	msg, ok := e.index[id]
+	if !ok {
+		msg = &types.Message{ID: id}
+		e.index[id] = msg
+		e.catalog.Messages = append(e.catalog.Messages, msg)
+	}
+	if plural != "" && msg.IDPlural == "" {
+		msg.IDPlural = plural
+	}
+	if !slices.Contains(msg.References, ref) {
+		msg.References = append(msg.References, ref)
+	}
+	for _, l := range []int{line - 1, line} {
+		if comment, ok := e.comments[l]; ok && !slices.Contains(msg.ExtractedComments, comment) {
+			msg.ExtractedComments = append(msg.ExtractedComments, comment)
+		}
+	}
+	if goFormatRe.MatchString(id + plural) {
+		msg.AddFlag("go-format")
+	}
+}
+
+// cleanKey removes the "pr:" prefix from a key.
+func cleanKey(key string) string {
+	return strings.TrimPrefix(key, "pr:")
+}
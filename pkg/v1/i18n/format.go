@@ -0,0 +1,352 @@
+package i18n
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Locale aware formatting of numbers, sizes, times and lists.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vanilla-os/sdk/pkg/v1/i18n/types"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// ErrUnsupportedLocale is returned by NewFormatter for the locales without
+// formatting data.
+var ErrUnsupportedLocale = errors.New("no formatting data for the locale")
+
+// Formatter formats values for a locale: numbers, percentages and byte
+// sizes use the CLDR data of golang.org/x/text, plural forms follow the
+// CLDR plural rules of the locale.
+type Formatter struct {
+	tag     language.Tag
+	printer *message.Printer
+	data    *formatData
+}
+
+// NewFormatter returns a Formatter for a locale. The names used in
+// relative times, durations, dates and lists are shipped with the package
+// for English, Italian, German, French, Spanish and Portuguese only, so an
+// error wrapping ErrUnsupportedLocale is returned for the other locales
+// instead of mixing English words with localized numbers; language.Und
+// formats as English.
+//
+// Example:
+//
+//	f, err := i18n.NewFormatter(language.Italian)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(f.Number(1234.5))                // 1.234,5
+//	fmt.Println(f.Size(1500000))                 // 1,5 MB
+//	fmt.Println(f.List([]string{"a", "b", "c"})) // a, b e c
+func NewFormatter(tag language.Tag) (*Formatter, error) {
+	data := englishData
+	if tag != language.Und {
+		var ok bool
+		if data, ok = formatDataFor(tag); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedLocale, tag)
+		}
+	}
+	return &Formatter{
+		tag:     tag,
+		printer: message.NewPrinter(tag),
+		data:    data,
+	}, nil
+}
+
+// Locale returns the locale of the formatter.
+//
+// Example:
+//
+//	fmt.Println(f.Locale()) // it
+func (f *Formatter) Locale() language.Tag {
+	return f.tag
+}
+
+// Number formats a number with the grouping and decimal separators of the
+// locale, with up to 3 fractional digits.
+//
+// Example:
+//
+//	f.Number(1234567.891) // "1,234,567.891" in English
+func (f *Formatter) Number(v any) string {
+	return f.printer.Sprint(number.Decimal(v))
+}
+
+// Decimal formats a number with exactly the given fractional digits.
+//
+// Example:
+//
+//	f.Decimal(3.14159, 2) // "3,14" in Italian
+func (f *Formatter) Decimal(v float64, digits int) string {
+	return f.printer.Sprint(number.Decimal(v, number.Scale(digits)))
+}
+
+// Percent formats a ratio as a percentage, 0.5 being 50%, with up to one
+// fractional digit.
+//
+// Example:
+//
+//	f.Percent(0.256) // "25.6%" in English
+func (f *Formatter) Percent(ratio float64) string {
+	return f.printer.Sprint(number.Percent(ratio, number.MaxFractionDigits(1)))
+}
+
+// Size formats a byte size with the SI units, multiples of 1000.
+//
+// Example:
+//
+//	f.Size(1500000) // "1.5 MB" in English, "1,5 Mo" in French
+func (f *Formatter) Size(bytes int64) string {
+	return f.size(bytes, 1000, f.data.sizeSI)
+}
+
+// SizeIEC formats a byte size with the IEC units, multiples of 1024.
+//
+// Example:
+//
+//	f.SizeIEC(1536) // "1.5 KiB" in English
+func (f *Formatter) SizeIEC(bytes int64) string {
+	return f.size(bytes, 1024, f.data.sizeIEC)
+}
+
+// size formats a byte size with the given base and units.
+func (f *Formatter) size(bytes int64, base float64, units [7]string) string {
+	value := float64(bytes)
+	sign := ""
+	if value < 0 {
+		sign, value = "-", -value
+	}
+
+	unit := 0
+	for value >= base && unit < len(units)-1 {
+		value /= base
+		unit++
+	}
+	if unit == 0 {
+		return sign + f.Number(int64(value)) + " " + units[0]
+	}
+	return sign + f.printer.Sprint(number.Decimal(value, number.MaxFractionDigits(2))) + " " + units[unit]
+}
+
+// RelativeTime formats the time elapsed since t or remaining until t, e.g.
+// "3 minutes ago" or "in 2 days", in the largest fitting unit.
+//
+// Example:
+//
+//	f.RelativeTime(time.Now().Add(-3 * time.Minute)) // "3 minutes ago"
+func (f *Formatter) RelativeTime(t time.Time) string {
+	return f.RelativeTimeFrom(t, time.Now())
+}
+
+// RelativeTimeFrom formats t relative to now, see RelativeTime.
+//
+// Example:
+//
+//	f.RelativeTimeFrom(start, start.Add(48*time.Hour)) // "2 days ago"
+func (f *Formatter) RelativeTimeFrom(t, now time.Time) string {
+	d := t.Sub(now)
+	pattern := f.data.future
+	if d < 0 {
+		d, pattern = -d, f.data.past
+	}
+	if d < time.Second {
+		return f.data.now
+	}
+
+	var unit timeUnit
+	var count int64
+	switch days := int64(d / (24 * time.Hour)); {
+	case d < time.Minute:
+		unit, count = unitSecond, int64(d/time.Second)
+	case d < time.Hour:
+		unit, count = unitMinute, int64(d/time.Minute)
+	case days < 1:
+		unit, count = unitHour, int64(d/time.Hour)
+	case days < 7:
+		unit, count = unitDay, days
+	case days < 30:
+		unit, count = unitWeek, days/7
+	case days < 365:
+		unit, count = unitMonth, days/30
+	default:
+		unit, count = unitYear, days/365
+	}
+
+	names := &f.data.units
+	if f.data.relativeUnits != nil {
+		names = f.data.relativeUnits
+	}
+	return fmt.Sprintf(pattern, f.quantity(names, unit, count))
+}
+
+// Duration formats a duration in days, hours, minutes and seconds, e.g.
+// "2 hours and 5 minutes", rounded to the second.
+//
+// Example:
+//
+//	f.Duration(90 * time.Minute) // "1 ora e 30 minuti" in Italian
+func (f *Formatter) Duration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	d = d.Round(time.Second)
+
+	var parts []string
+	for _, part := range []struct {
+		unit timeUnit
+		size time.Duration
+	}{
+		{unitDay, 24 * time.Hour},
+		{unitHour, time.Hour},
+		{unitMinute, time.Minute},
+		{unitSecond, time.Second},
+	} {
+		if count := int64(d / part.size); count > 0 {
+			parts = append(parts, f.quantity(&f.data.units, part.unit, count))
+			d -= time.Duration(count) * part.size
+		}
+	}
+	if len(parts) == 0 {
+		return f.quantity(&f.data.units, unitSecond, 0)
+	}
+	return f.List(parts)
+}
+
+// quantity formats a count of a time unit, choosing the plural form with
+// the CLDR rules of the locale. The supported locales only distinguish the
+// one and other forms of the integers.
+func (f *Formatter) quantity(names *unitNames, unit timeUnit, count int64) string {
+	form := 1
+	if plural.Cardinal.MatchPlural(f.tag, int(count), 0, 0, 0, 0) == plural.One {
+		form = 0
+	}
+	return fmt.Sprintf(names[unit][form], f.Number(count))
+}
+
+// Date formats the date of t in the given style.
+//
+// Example:
+//
+//	f.Date(t, types.DateLong) // "2 gennaio 2006" in Italian
+func (f *Formatter) Date(t time.Time, style types.DateStyle) string {
+	if style < types.DateShort || style > types.DateFull {
+		style = types.DateMedium
+	}
+	return f.formatPattern(t, f.data.dates[style])
+}
+
+// Time formats the time of day of t in the short form, e.g. "3:04 PM" in
+// English or "15:04" in Italian.
+//
+// Example:
+//
+//	f.Time(time.Now())
+func (f *Formatter) Time(t time.Time) string {
+	return f.formatPattern(t, f.data.time)
+}
+
+// formatPattern formats t with a CLDR date pattern, supporting the d, M,
+// y, E, H, h, m and a fields and quoted literals.
+func (f *Formatter) formatPattern(t time.Time, pattern string) string {
+	var b strings.Builder
+	pad := func(n, width int) string {
+		s := strconv.Itoa(n)
+		for len(s) < width {
+			s = "0" + s
+		}
+		return s
+	}
+
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		if c == '\'' {
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				b.WriteString(pattern[i+1:])
+				break
+			}
+			b.WriteString(pattern[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == c {
+			n++
+		}
+		switch c {
+		case 'd':
+			b.WriteString(pad(t.Day(), n))
+		case 'M':
+			switch {
+			case n >= 4:
+				b.WriteString(f.data.months[t.Month()-1])
+			case n == 3:
+				b.WriteString(f.data.monthsAbbr[t.Month()-1])
+			default:
+				b.WriteString(pad(int(t.Month()), n))
+			}
+		case 'y':
+			if n == 2 {
+				b.WriteString(pad(t.Year()%100, 2))
+			} else {
+				b.WriteString(pad(t.Year(), n))
+			}
+		case 'E':
+			b.WriteString(f.data.weekdays[t.Weekday()])
+		case 'H':
+			b.WriteString(pad(t.Hour(), n))
+		case 'h':
+			hour := t.Hour() % 12
+			if hour == 0 {
+				hour = 12
+			}
+			b.WriteString(pad(hour, n))
+		case 'm':
+			b.WriteString(pad(t.Minute(), n))
+		case 'a':
+			b.WriteString(f.data.dayPeriods[t.Hour()/12])
+		default:
+			b.WriteString(pattern[i : i+n])
+		}
+		i += n
+	}
+	return b.String()
+}
+
+// List joins items with the conjunction of the locale, e.g. "a, b, and c"
+// in English or "a, b e c" in Italian.
+//
+// Example:
+//
+//	f.List([]string{"Mirko", "Luca", "Pietro"}) // "Mirko, Luca und Pietro" in German
+func (f *Formatter) List(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " " + f.data.and + " " + items[1]
+	}
+
+	last := len(items) - 1
+	separator := " "
+	if f.data.serialComma {
+		separator = ", "
+	}
+	return strings.Join(items[:last], ", ") + separator + f.data.and + " " + items[last]
+}
@@ -0,0 +1,247 @@
+package i18n
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Locale data for the formatting of dates, times and lists.
+*/
+
+import "golang.org/x/text/language"
+
+// timeUnit is a unit of the relative times and durations.
+type timeUnit int
+
+const (
+	unitSecond timeUnit = iota
+	unitMinute
+	unitHour
+	unitDay
+	unitWeek
+	unitMonth
+	unitYear
+	unitCount
+)
+
+// unitNames holds the singular and plural form of each time unit, "%s"
+// being replaced by the number.
+type unitNames [unitCount][2]string
+
+// formatData holds the CLDR data of a locale not provided by
+// golang.org/x/text: the names of the time units, the relative time
+// patterns, the list conjunction and the date patterns.
+type formatData struct {
+	// units are the names of the time units in durations
+	units unitNames
+
+	// relativeUnits are the names of the time units in relative times,
+	// if they differ from units (e.g. the German dative)
+	relativeUnits *unitNames
+
+	// past, future and now are the relative time patterns
+	past, future, now string
+
+	// and is the conjunction of lists, serialComma reports whether a comma
+	// is placed before it
+	and         string
+	serialComma bool
+
+	// months, monthsAbbr and weekdays are the names used in dates,
+	// weekdays start on Sunday
+	months, monthsAbbr [12]string
+	weekdays           [7]string
+
+	// dates holds the CLDR date pattern of each types.DateStyle, time the
+	// short time pattern
+	dates [4]string
+	time  string
+
+	// dayPeriods are the abbreviated names of the morning and afternoon,
+	// used by the 12-hour time patterns
+	dayPeriods [2]string
+
+	// sizeSI and sizeIEC are the byte size units
+	sizeSI, sizeIEC [7]string
+}
+
+var (
+	sizeSIDefault  = [7]string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	sizeIECDefault = [7]string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+)
+
+var englishData = &formatData{
+	units: unitNames{
+		{"%s second", "%s seconds"},
+		{"%s minute", "%s minutes"},
+		{"%s hour", "%s hours"},
+		{"%s day", "%s days"},
+		{"%s week", "%s weeks"},
+		{"%s month", "%s months"},
+		{"%s year", "%s years"},
+	},
+	past:        "%s ago",
+	future:      "in %s",
+	now:         "now",
+	and:         "and",
+	serialComma: true,
+	months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	monthsAbbr:  [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	weekdays:    [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	dates:       [4]string{"M/d/yy", "MMM d, y", "MMMM d, y", "EEEE, MMMM d, y"},
+	time:        "h:mm a",
+	dayPeriods:  [2]string{"AM", "PM"},
+	sizeSI:      sizeSIDefault,
+	sizeIEC:     sizeIECDefault,
+}
+
+// localeData maps the locales, as language or language_TERRITORY, to their
+// formatting data, taken from CLDR. NewFormatter fails for the locales not
+// listed here.
+var localeData = map[string]*formatData{
+	"en": englishData,
+	"en_GB": func() *formatData {
+		data := *englishData
+		data.serialComma = false
+		data.dates = [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"}
+		data.time = "HH:mm"
+		return &data
+	}(),
+	"it": {
+		units: unitNames{
+			{"%s secondo", "%s secondi"},
+			{"%s minuto", "%s minuti"},
+			{"%s ora", "%s ore"},
+			{"%s giorno", "%s giorni"},
+			{"%s settimana", "%s settimane"},
+			{"%s mese", "%s mesi"},
+			{"%s anno", "%s anni"},
+		},
+		past:       "%s fa",
+		future:     "tra %s",
+		now:        "ora",
+		and:        "e",
+		months:     [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		monthsAbbr: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		weekdays:   [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		dates:      [4]string{"dd/MM/yy", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		time:       "HH:mm",
+		dayPeriods: [2]string{"AM", "PM"},
+		sizeSI:     sizeSIDefault,
+		sizeIEC:    sizeIECDefault,
+	},
+	"de": {
+		units: unitNames{
+			{"%s Sekunde", "%s Sekunden"},
+			{"%s Minute", "%s Minuten"},
+			{"%s Stunde", "%s Stunden"},
+			{"%s Tag", "%s Tage"},
+			{"%s Woche", "%s Wochen"},
+			{"%s Monat", "%s Monate"},
+			{"%s Jahr", "%s Jahre"},
+		},
+		relativeUnits: &unitNames{
+			{"%s Sekunde", "%s Sekunden"},
+			{"%s Minute", "%s Minuten"},
+			{"%s Stunde", "%s Stunden"},
+			{"%s Tag", "%s Tagen"},
+			{"%s Woche", "%s Wochen"},
+			{"%s Monat", "%s Monaten"},
+			{"%s Jahr", "%s Jahren"},
+		},
+		past:       "vor %s",
+		future:     "in %s",
+		now:        "jetzt",
+		and:        "und",
+		months:     [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		monthsAbbr: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		weekdays:   [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		dates:      [4]string{"dd.MM.yy", "dd.MM.y", "d. MMMM y", "EEEE, d. MMMM y"},
+		time:       "HH:mm",
+		dayPeriods: [2]string{"AM", "PM"},
+		sizeSI:     sizeSIDefault,
+		sizeIEC:    sizeIECDefault,
+	},
+	"fr": {
+		units: unitNames{
+			{"%s seconde", "%s secondes"},
+			{"%s minute", "%s minutes"},
+			{"%s heure", "%s heures"},
+			{"%s jour", "%s jours"},
+			{"%s semaine", "%s semaines"},
+			{"%s mois", "%s mois"},
+			{"%s an", "%s ans"},
+		},
+		past:       "il y a %s",
+		future:     "dans %s",
+		now:        "maintenant",
+		and:        "et",
+		months:     [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		monthsAbbr: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:   [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		dates:      [4]string{"dd/MM/y", "d MMM y", "d MMMM y", "EEEE d MMMM y"},
+		time:       "HH:mm",
+		dayPeriods: [2]string{"AM", "PM"},
+		sizeSI:     [7]string{"o", "ko", "Mo", "Go", "To", "Po", "Eo"},
+		sizeIEC:    [7]string{"o", "Kio", "Mio", "Gio", "Tio", "Pio", "Eio"},
+	},
+	"es": {
+		units: unitNames{
+			{"%s segundo", "%s segundos"},
+			{"%s minuto", "%s minutos"},
+			{"%s hora", "%s horas"},
+			{"%s día", "%s días"},
+			{"%s semana", "%s semanas"},
+			{"%s mes", "%s meses"},
+			{"%s año", "%s años"},
+		},
+		past:       "hace %s",
+		future:     "dentro de %s",
+		now:        "ahora",
+		and:        "y",
+		months:     [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		monthsAbbr: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		weekdays:   [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		dates:      [4]string{"d/M/yy", "d MMM y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		time:       "H:mm",
+		dayPeriods: [2]string{"a. m.", "p. m."},
+		sizeSI:     sizeSIDefault,
+		sizeIEC:    sizeIECDefault,
+	},
+	"pt": {
+		units: unitNames{
+			{"%s segundo", "%s segundos"},
+			{"%s minuto", "%s minutos"},
+			{"%s hora", "%s horas"},
+			{"%s dia", "%s dias"},
+			{"%s semana", "%s semanas"},
+			{"%s mês", "%s meses"},
+			{"%s ano", "%s anos"},
+		},
+		past:       "há %s",
+		future:     "em %s",
+		now:        "agora",
+		and:        "e",
+		months:     [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		monthsAbbr: [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		weekdays:   [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		dates:      [4]string{"dd/MM/y", "d 'de' MMM 'de' y", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		time:       "HH:mm",
+		dayPeriods: [2]string{"AM", "PM"},
+		sizeSI:     sizeSIDefault,
+		sizeIEC:    sizeIECDefault,
+	},
+}
+
+// formatDataFor returns the formatting data of a locale, falling back to
+// its language. It reports false if there is none.
+func formatDataFor(tag language.Tag) (*formatData, bool) {
+	base, _ := tag.Base()
+	if region, confidence := tag.Region(); confidence == language.Exact {
+		if data, ok := localeData[base.String()+"_"+region.String()]; ok {
+			return data, true
+		}
+	}
+	data, ok := localeData[base.String()]
+	return data, ok
+}
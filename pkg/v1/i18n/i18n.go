@@ -18,58 +18,128 @@ import (
 )
 
 // NewLocalizer creates a new localizer for the application, the localizer is
-// used to localize strings in the application. The localizer requires the
-// application and the locale to be passed in, the locale is the language
-// the user wants to use. If the locale is not found or is empty, the
-// localizer will default to English, assuming it as the fallback.
+// used to localize strings in the application. The locale is the language
+// the user wants to use, or a colon separated list of languages in order of
+// preference as in the LANGUAGE environment variable. It is negotiated
+// against the languages listed in the LINGUAS file, following the fallback
+// chain of each locale (e.g. pt_BR, then pt). If no language matches, the
+// localizer defaults to English, assuming it as the fallback, or returns
+// the strings untranslated if no English catalog is available.
 //
 // Example:
 //
-//	t, err := i18n.NewLocalizer(app, "")
+//	t, err := i18n.NewLocalizer(localesFS, "com.vanilla-os.batsignal", "pt_BR.UTF-8:it")
 //	if err != nil {
 //		fmt.Printf("Error: %v\n", err)
 //		return
 //	}
 //	fmt.Println(t.Get("I am Batman!"))
-//	fmt.Println(t.Get("I am Batman!"))
 func NewLocalizer(localeFS fs.FS, defaultDomain string, locale string) (*spreak.Localizer, error) {
-	foundLocale, err := language.Parse(locale)
-	if err != nil {
-		foundLocale = language.English
+	return newLocalizer(localeFS, defaultDomain, locale)
+}
+
+// NewPseudoLocalizer creates a localizer for the pseudo-locale selected by
+// PseudoTranslator from a locale, or a colon separated list of locales.
+// Every string returned by the localizer, translated or not, is
+// pseudo-translated, so that the pseudo-locale applies to the code using
+// the localizer directly too. The English catalog is used, if available;
+// localeFS can be nil to pseudo-translate the source strings.
+//
+// Example:
+//
+//	t, err := i18n.NewPseudoLocalizer(localesFS, "com.vanilla-os.batsignal", i18n.PseudoLocale)
+//	if err != nil {
+//		fmt.Printf("Error: %v\n", err)
+//		return
+//	}
+//	fmt.Println(t.Get("I am Batman!")) // [ÎÎ ååɱ Ɓååţɱååñ!]
+func NewPseudoLocalizer(localeFS fs.FS, defaultDomain string, locale string) (*spreak.Localizer, error) {
+	_, pseudo := PseudoTranslator(locale)
+	if pseudo == nil {
+		return nil, fmt.Errorf("no pseudo-locale in %q", locale)
 	}
 
-	// we need to get the supported languages from the locales file system
-	// to do so we expect a LINGUAS file to be present
-	linguas, err := fs.ReadFile(localeFS, "LINGUAS")
-	if err != nil {
-		return nil, fmt.Errorf("no LINGUAS file found: %v", err)
+	printer := &pseudoPrinter{Printer: spreak.NewDefaultPrinter(), pseudo: pseudo}
+	return newLocalizer(localeFS, defaultDomain, "en", spreak.WithPrinter(printer))
+}
+
+// newLocalizer creates a localizer negotiating a locale against the
+// languages of the locales file system, if any, with additional bundle
+// options.
+func newLocalizer(localeFS fs.FS, defaultDomain string, locale string, extra ...spreak.BundleOption) (*spreak.Localizer, error) {
+	options := []spreak.BundleOption{
+		spreak.WithSourceLanguage(language.MustParse("qaa")),
+		spreak.WithDefaultDomain(defaultDomain),
 	}
+	options = append(options, extra...)
+
+	var foundLocale []interface{}
+	if localeFS != nil {
+		// we need to get the supported languages from the locales file
+		// system to do so we expect a LINGUAS file to be present
+		languages, err := Languages(localeFS)
+		if err != nil {
+			return nil, err
+		}
 
-	// spreak.WithLanguage requires a slice of interfaces
-	supportedLanguages := make([]interface{}, 0)
-	for _, l := range strings.Split(string(linguas), "\n") {
-		if l == "" {
-			continue
+		// spreak.WithLanguage requires a slice of interfaces
+		supportedLanguages := make([]interface{}, 0)
+		for _, l := range languages {
+			if tag := LocaleTag(l); tag != language.Und {
+				supportedLanguages = append(supportedLanguages, tag)
+			}
+		}
+
+		options = append(options,
+			spreak.WithFilesystemLoader(defaultDomain,
+				spreak.WithFs(localeFS),
+				spreak.WithPoDecoder(),
+				spreak.WithMoDecoder(),
+			),
+			spreak.WithLanguage(supportedLanguages...),
+		)
+
+		found := Negotiate(languages, strings.Split(locale, ":"))
+		if found == "" {
+			found = Negotiate(languages, []string{"en"})
+		}
+		if found != "" {
+			foundLocale = append(foundLocale, LocaleTag(found))
+			options = append(options, spreak.WithRequiredLanguage(foundLocale...))
 		}
-		supportedLanguages = append(supportedLanguages, language.MustParse(l))
 	}
 
 	// we need to create a new bundle for the localizer, here we use the RDNN
 	// as the default localizer domain
-	bundle, err := spreak.NewBundle(
-		spreak.WithSourceLanguage(language.MustParse("qaa")),
-		spreak.WithDefaultDomain(defaultDomain),
-		spreak.WithFilesystemLoader(defaultDomain,
-			spreak.WithFs(localeFS),
-			spreak.WithPoDecoder(),
-			spreak.WithMoDecoder(),
-		),
-		spreak.WithRequiredLanguage(foundLocale),
-		spreak.WithLanguage(supportedLanguages...),
-	)
+	bundle, err := spreak.NewBundle(options...)
 	if err != nil {
 		return nil, err
 	}
 
-	return spreak.NewLocalizer(bundle, foundLocale), nil
+	return spreak.NewLocalizer(bundle, foundLocale...), nil
+}
+
+// Languages returns the languages listed in the LINGUAS file of the locales
+// file system, separated by spaces or newlines as in the gettext
+// convention.
+//
+// Example:
+//
+//	languages, err := i18n.Languages(localesFS)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Available in %d languages\n", len(languages))
+func Languages(localeFS fs.FS) ([]string, error) {
+	linguas, err := fs.ReadFile(localeFS, "LINGUAS")
+	if err != nil {
+		return nil, fmt.Errorf("no LINGUAS file found: %v", err)
+	}
+
+	var languages []string
+	for _, line := range strings.Split(string(linguas), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		languages = append(languages, strings.Fields(line)...)
+	}
+	return languages, nil
 }
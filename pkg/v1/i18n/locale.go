@@ -0,0 +1,209 @@
+package i18n
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Locale normalization and negotiation.
+*/
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// scriptModifiers maps the POSIX locale modifiers selecting a script to
+// their ISO 15924 code.
+var scriptModifiers = map[string]string{
+	"latin":      "Latn",
+	"cyrillic":   "Cyrl",
+	"devanagari": "Deva",
+	"arabic":     "Arab",
+}
+
+// NormalizeLocale converts a POSIX locale name or a BCP 47 tag into the
+// form used by gettext catalogs: the charset and the modifiers other than
+// scripts are dropped, the language is lowercased and the territory
+// uppercased. The "C" and "POSIX" locales result in an empty string.
+//
+// Example:
+//
+//	i18n.NormalizeLocale("it_IT.UTF-8@euro") // "it_IT"
+//	i18n.NormalizeLocale("pt-br")            // "pt_BR"
+//	i18n.NormalizeLocale("sr_RS@latin")      // "sr_RS@latin"
+func NormalizeLocale(locale string) string {
+	locale = strings.TrimSpace(locale)
+	locale, modifier, _ := strings.Cut(locale, "@")
+	locale, _, _ = strings.Cut(locale, ".")
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return ""
+	}
+
+	parts := strings.FieldsFunc(locale, func(r rune) bool {
+		return r == '_' || r == '-'
+	})
+	if len(parts) == 0 {
+		return ""
+	}
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 4:
+			// script, e.g. Hans
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		default:
+			parts[i] = strings.ToUpper(parts[i])
+		}
+	}
+
+	normalized := strings.Join(parts, "_")
+	if _, ok := scriptModifiers[strings.ToLower(modifier)]; ok {
+		normalized += "@" + strings.ToLower(modifier)
+	}
+	return normalized
+}
+
+// LocaleTag returns the BCP 47 tag of a locale, e.g. sr-Latn-RS for
+// "sr_RS@latin", or language.Und if the locale is not valid.
+//
+// Example:
+//
+//	tag := i18n.LocaleTag("it_IT.UTF-8")
+//	fmt.Println(tag) // it-IT
+func LocaleTag(locale string) language.Tag {
+	normalized := NormalizeLocale(locale)
+	if normalized == "" {
+		return language.Und
+	}
+
+	normalized, modifier, _ := strings.Cut(normalized, "@")
+	parts := strings.Split(normalized, "_")
+	if script, ok := scriptModifiers[modifier]; ok {
+		parts = append(parts[:1], append([]string{script}, parts[1:]...)...)
+	}
+
+	tag, err := language.Parse(strings.Join(parts, "-"))
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
+
+// FallbackChain returns the locales to try, in order, for a locale: the
+// normalized locale followed by its less specific forms.
+//
+// Example:
+//
+//	i18n.FallbackChain("pt_BR.UTF-8") // ["pt_BR", "pt"]
+//	i18n.FallbackChain("sr_RS@latin") // ["sr_RS@latin", "sr@latin", "sr_RS", "sr"]
+func FallbackChain(locale string) []string {
+	normalized := NormalizeLocale(locale)
+	if normalized == "" {
+		return nil
+	}
+
+	normalized, modifier, _ := strings.Cut(normalized, "@")
+	parts := strings.Split(normalized, "_")
+
+	var chain []string
+	for _, withModifier := range []bool{true, false} {
+		if withModifier && modifier == "" {
+			continue
+		}
+		for i := len(parts); i > 0; i-- {
+			name := strings.Join(parts[:i], "_")
+			if withModifier {
+				name += "@" + modifier
+			}
+			chain = append(chain, name)
+		}
+	}
+	return chain
+}
+
+// UserLocales returns the locales preferred by the user, in order, as
+// gettext does: the colon separated LANGUAGE list, unless the locale is
+// "C", followed by the first locale set among LC_ALL, LC_MESSAGES and
+// LANG.
+//
+// Example:
+//
+//	// LANGUAGE=pt_BR:it LANG=en_US.UTF-8
+//	locales := i18n.UserLocales() // ["pt_BR", "it", "en_US"]
+func UserLocales() []string {
+	var locale string
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale = os.Getenv(env); locale != "" {
+			break
+		}
+	}
+
+	var locales []string
+	add := func(l string) {
+		if l = NormalizeLocale(l); l != "" && !slices.Contains(locales, l) {
+			locales = append(locales, l)
+		}
+	}
+
+	if NormalizeLocale(locale) != "" || locale == "" {
+		for _, l := range strings.Split(os.Getenv("LANGUAGE"), ":") {
+			add(l)
+		}
+	}
+	add(locale)
+	return locales
+}
+
+// Negotiate returns the first of the available locales matching the
+// preferred ones, in order of preference. Each preferred locale is matched
+// through its fallback chain, e.g. "pt_BR" matches "pt" if no "pt_BR"
+// catalog is available, then against any available locale of the same
+// language. The available locale is returned as it is spelled, or an empty
+// string if none matches.
+//
+// Example:
+//
+//	languages, err := i18n.Languages(localesFS)
+//	if err != nil {
+//		return err
+//	}
+//	locale := i18n.Negotiate(languages, i18n.UserLocales())
+func Negotiate(available, preferred []string) string {
+	normalized := make([]string, len(available))
+	for i, l := range available {
+		normalized[i] = NormalizeLocale(l)
+	}
+
+	for _, wanted := range preferred {
+		chain := FallbackChain(wanted)
+		for _, candidate := range chain {
+			for i, l := range normalized {
+				if l == candidate {
+					return available[i]
+				}
+			}
+		}
+		if len(chain) == 0 {
+			continue
+		}
+
+		base := chain[len(chain)-1]
+		for i, l := range normalized {
+			if l != "" && baseLanguage(l) == base {
+				return available[i]
+			}
+		}
+	}
+	return ""
+}
+
+// baseLanguage returns the language of a normalized locale.
+func baseLanguage(locale string) string {
+	locale, _, _ = strings.Cut(locale, "@")
+	locale, _, _ = strings.Cut(locale, "_")
+	return locale
+}
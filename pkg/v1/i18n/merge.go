@@ -0,0 +1,271 @@
+package i18n
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Merging of templates into existing translations.
+*/
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/vanilla-os/sdk/pkg/v1/i18n/types"
+)
+
+// fuzzyThreshold is the minimum similarity for a translation to be reused
+// as a fuzzy match, the same used by msgmerge.
+const fuzzyThreshold = 0.6
+
+// npluralsRe extracts the number of plural forms from the Plural-Forms
+// header field.
+var npluralsRe = regexp.MustCompile(`nplurals\s*=\s*(\d+)`)
+
+// Merge updates the translations in po with the entries of template, as
+// msgmerge does: translations of unchanged strings are kept, the ones of
+// changed strings are reused for the most similar new string and marked
+// as fuzzy, together with the previous msgid, new strings are added
+// untranslated and translated strings no longer in the template are kept
+// as obsolete entries. References, extracted comments and format flags
+// are taken from the template, translator comments from po.
+//
+// Example:
+//
+//	template, err := i18n.Extract(".", types.ExtractOptions{Package: "batsignal"})
+//	if err != nil {
+//		return err
+//	}
+//	italian, err := i18n.ReadPO("locales/it/LC_MESSAGES/batsignal.po")
+//	if err != nil {
+//		return err
+//	}
+//	merged := i18n.Merge(italian, template)
+//	err = i18n.WritePOFile("locales/it/LC_MESSAGES/batsignal.po", merged)
+func Merge(po, template *types.Catalog) *types.Catalog {
+	merged := &types.Catalog{Header: cloneMessage(po.Header)}
+	if merged.Header == nil {
+		merged.Header = cloneMessage(template.Header)
+	}
+	if date := template.HeaderField("POT-Creation-Date"); date != "" {
+		merged.SetHeaderField("POT-Creation-Date", date)
+	}
+	nplurals := pluralCount(po)
+
+	index := make(map[string]*types.Message)
+	for _, msg := range po.Messages {
+		if _, ok := index[msg.Key()]; !ok || !msg.Obsolete {
+			index[msg.Key()] = msg
+		}
+	}
+
+	used := make(map[*types.Message]bool)
+	for _, tmsg := range template.Messages {
+		msg := &types.Message{
+			Context:           tmsg.Context,
+			ID:                tmsg.ID,
+			IDPlural:          tmsg.IDPlural,
+			ExtractedComments: slices.Clone(tmsg.ExtractedComments),
+			References:        slices.Clone(tmsg.References),
+		}
+		for _, flag := range tmsg.Flags {
+			if flag != "fuzzy" {
+				msg.AddFlag(flag)
+			}
+		}
+
+		if old, ok := index[tmsg.Key()]; ok {
+			used[old] = true
+			msg.Comments = slices.Clone(old.Comments)
+			msg.Str = adaptTranslations(old.Str, tmsg.IDPlural != "", nplurals)
+			if old.Fuzzy() {
+				msg.AddFlag("fuzzy")
+				msg.PreviousContext, msg.PreviousID = old.PreviousContext, old.PreviousID
+			}
+			if old.IDPlural != tmsg.IDPlural && hasTranslation(old) {
+				msg.AddFlag("fuzzy")
+			}
+		} else if similar := findSimilar(po, tmsg); similar != nil {
+			msg.Comments = slices.Clone(similar.Comments)
+			msg.Str = adaptTranslations(similar.Str, tmsg.IDPlural != "", nplurals)
+			msg.AddFlag("fuzzy")
+			msg.PreviousID = similar.ID
+			if similar.Context != tmsg.Context {
+				msg.PreviousContext = similar.Context
+			}
+		} else {
+			msg.Str = adaptTranslations(nil, tmsg.IDPlural != "", nplurals)
+		}
+		merged.Messages = append(merged.Messages, msg)
+	}
+
+	for _, old := range po.Messages {
+		if used[old] || !hasTranslation(old) || merged.Find(old.Context, old.ID) != nil {
+			continue
+		}
+		obsolete := cloneMessage(old)
+		obsolete.Obsolete = true
+		obsolete.References = nil
+		obsolete.ExtractedComments = nil
+		merged.Messages = append(merged.Messages, obsolete)
+	}
+	return merged
+}
+
+// MergeFile merges template into the PO file at path, updating it in
+// place like msgmerge --update. If the file does not exist, it is created
+// from the template with the Language header field set to lang.
+//
+// Example:
+//
+//	err := i18n.MergeFile("locales/it/LC_MESSAGES/batsignal.po", "it", template)
+func MergeFile(path, lang string, template *types.Catalog) error {
+	po, err := ReadPO(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		po = &types.Catalog{Header: cloneMessage(template.Header)}
+		po.Header.RemoveFlag("fuzzy")
+		po.SetHeaderField("Language", lang)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	return WritePOFile(path, Merge(po, template))
+}
+
+// UpdateCatalogs writes template into dir/<domain>.pot and merges it into
+// the catalog of every language listed in dir/LINGUAS, laid out as
+// dir/<lang>/LC_MESSAGES/<domain>.po. Missing catalogs are created. It
+// returns the paths of the written files.
+//
+// Example:
+//
+//	template, err := i18n.Extract(".", types.ExtractOptions{Package: "batsignal"})
+//	if err != nil {
+//		return err
+//	}
+//	files, err := i18n.UpdateCatalogs("locales", "com.vanilla-os.batsignal", template)
+func UpdateCatalogs(dir, domain string, template *types.Catalog) ([]string, error) {
+	potPath := filepath.Join(dir, domain+".pot")
+	if err := WritePOFile(potPath, template); err != nil {
+		return nil, err
+	}
+	files := []string{potPath}
+
+	languages, err := Languages(os.DirFS(dir))
+	if err != nil {
+		return files, err
+	}
+	for _, lang := range languages {
+		path := filepath.Join(dir, lang, "LC_MESSAGES", domain+".po")
+		if err := MergeFile(path, lang, template); err != nil {
+			return files, err
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// pluralCount returns the number of plural forms of a catalog, 2 if not
+// specified by its header.
+func pluralCount(catalog *types.Catalog) int {
+	match := npluralsRe.FindStringSubmatch(catalog.HeaderField("Plural-Forms"))
+	if match == nil {
+		return 2
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil || n < 1 {
+		return 2
+	}
+	return n
+}
+
+// adaptTranslations returns the translations resized for a singular or a
+// plural entry.
+func adaptTranslations(str []string, plural bool, nplurals int) []string {
+	if !plural {
+		if len(str) == 0 {
+			return []string{""}
+		}
+		return []string{str[0]}
+	}
+
+	adapted := make([]string, nplurals)
+	copy(adapted, str)
+	return adapted
+}
+
+// hasTranslation reports whether any translation of an entry is set.
+func hasTranslation(msg *types.Message) bool {
+	return slices.ContainsFunc(msg.Str, func(s string) bool {
+		return s != ""
+	})
+}
+
+// findSimilar returns the translated entry of po with the same context most
+// similar to msg, or nil if none is similar enough.
+func findSimilar(po *types.Catalog, msg *types.Message) *types.Message {
+	var best *types.Message
+	bestScore := fuzzyThreshold
+	for _, candidate := range po.Messages {
+		if candidate.Context != msg.Context || !hasTranslation(candidate) {
+			continue
+		}
+		if score := similarity(candidate.ID, msg.ID); score >= bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	return best
+}
+
+// similarity returns a ratio between 0 and 1 of how similar two strings
+// are, based on their edit distance.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	if float64(min(len(ra), len(rb)))/float64(longest) < fuzzyThreshold {
+		return 0
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
+
+// cloneMessage returns a deep copy of an entry.
+func cloneMessage(msg *types.Message) *types.Message {
+	if msg == nil {
+		return nil
+	}
+	clone := *msg
+	clone.Str = slices.Clone(msg.Str)
+	clone.Comments = slices.Clone(msg.Comments)
+	clone.ExtractedComments = slices.Clone(msg.ExtractedComments)
+	clone.References = slices.Clone(msg.References)
+	clone.Flags = slices.Clone(msg.Flags)
+	return &clone
+}
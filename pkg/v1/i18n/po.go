@@ -0,0 +1,475 @@
+package i18n
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Reading and writing of PO and POT catalogs.
+*/
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/vanilla-os/sdk/pkg/v1/i18n/types"
+)
+
+// ParsePO parses a PO or POT catalog, including plural forms, contexts,
+// multi-line strings, flags, previous strings and obsolete entries.
+//
+// Example:
+//
+//	f, err := os.Open("locales/it/LC_MESSAGES/batsignal.po")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	catalog, err := i18n.ParsePO(f)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("%d entries\n", len(catalog.Messages))
+func ParsePO(r io.Reader) (*types.Catalog, error) {
+	p := poParser{catalog: &types.Catalog{}}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		p.line++
+		if err := p.parseLine(strings.TrimSpace(scanner.Text())); err != nil {
+			return nil, fmt.Errorf("line %d: %w", p.line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	p.flush()
+	return p.catalog, nil
+}
+
+// ReadPO parses the PO or POT catalog at path.
+//
+// Example:
+//
+//	catalog, err := i18n.ReadPO("locales/batsignal.pot")
+//	if err != nil {
+//		return err
+//	}
+func ReadPO(path string) (*types.Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	catalog, err := ParsePO(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+// poParser holds the state of ParsePO.
+type poParser struct {
+	catalog *types.Catalog
+	line    int
+
+	// current is the entry being parsed, hasID reports whether its msgid
+	// was already found
+	current *types.Message
+	hasID   bool
+
+	// target is the string the continuation lines are appended to
+	target *string
+}
+
+// entry returns the entry being parsed, starting a new one if the current
+// one is complete.
+func (p *poParser) entry() *types.Message {
+	if p.current == nil {
+		p.current = &types.Message{}
+	}
+	return p.current
+}
+
+// flush adds the entry being parsed to the catalog.
+func (p *poParser) flush() {
+	if p.current != nil && p.hasID {
+		if p.current.ID == "" && p.current.Context == "" && p.catalog.Header == nil && !p.current.Obsolete {
+			p.catalog.Header = p.current
+		} else {
+			p.catalog.Messages = append(p.catalog.Messages, p.current)
+		}
+	}
+	p.current = nil
+	p.hasID = false
+	p.target = nil
+}
+
+// parseLine parses a single, trimmed, line.
+func (p *poParser) parseLine(line string) error {
+	if line == "" {
+		p.flush()
+		return nil
+	}
+
+	obsolete := false
+	if strings.HasPrefix(line, "#~") {
+		obsolete = true
+		line = strings.TrimSpace(line[2:])
+		if line == "" {
+			return nil
+		}
+		if strings.HasPrefix(line, "|") {
+			line = "#" + line
+		}
+	}
+
+	if strings.HasPrefix(line, "#") {
+		return p.parseComment(line, obsolete)
+	}
+
+	if strings.HasPrefix(line, `"`) {
+		if p.target == nil {
+			return fmt.Errorf("unexpected string without a keyword")
+		}
+		value, err := unquotePO(line)
+		if err != nil {
+			return err
+		}
+		*p.target += value
+		return nil
+	}
+
+	keyword, rest, _ := strings.Cut(line, " ")
+	value, err := unquotePO(strings.TrimSpace(rest))
+	if err != nil {
+		return err
+	}
+
+	if (keyword == "msgctxt" || keyword == "msgid") && p.hasID {
+		p.flush()
+	}
+	msg := p.entry()
+	msg.Obsolete = msg.Obsolete || obsolete
+
+	switch {
+	case keyword == "msgctxt":
+		msg.Context = value
+		p.target = &msg.Context
+	case keyword == "msgid":
+		msg.ID = value
+		p.hasID = true
+		p.target = &msg.ID
+	case keyword == "msgid_plural":
+		msg.IDPlural = value
+		p.target = &msg.IDPlural
+	case keyword == "msgstr":
+		msg.Str = append(msg.Str, value)
+		p.target = &msg.Str[len(msg.Str)-1]
+	case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+		index, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+		if err != nil || index < 0 {
+			return fmt.Errorf("invalid plural index in %s", keyword)
+		}
+		for len(msg.Str) <= index {
+			msg.Str = append(msg.Str, "")
+		}
+		msg.Str[index] = value
+		p.target = &msg.Str[index]
+	default:
+		return fmt.Errorf("unknown keyword %q", keyword)
+	}
+	return nil
+}
+
+// parseComment parses a comment line, including the previous strings.
+func (p *poParser) parseComment(line string, obsolete bool) error {
+	if p.hasID && !strings.HasPrefix(line, "#|") {
+		p.flush()
+	}
+	msg := p.entry()
+	msg.Obsolete = msg.Obsolete || obsolete
+
+	kind, text := line[:1], line[1:]
+	if len(line) > 1 {
+		kind, text = line[:2], line[2:]
+	}
+	text = strings.TrimSpace(text)
+
+	switch kind {
+	case "#.":
+		msg.ExtractedComments = append(msg.ExtractedComments, text)
+	case "#:":
+		msg.References = append(msg.References, strings.Fields(text)...)
+	case "#,":
+		for _, flag := range strings.Split(text, ",") {
+			if flag = strings.TrimSpace(flag); flag != "" {
+				msg.AddFlag(flag)
+			}
+		}
+	case "#|":
+		keyword, rest, _ := strings.Cut(text, " ")
+		if strings.HasPrefix(keyword, `"`) {
+			rest = keyword + " " + rest
+			keyword = ""
+		}
+		value, err := unquotePO(strings.TrimSpace(rest))
+		if err != nil {
+			return err
+		}
+		switch keyword {
+		case "msgctxt":
+			msg.PreviousContext = value
+			p.target = &msg.PreviousContext
+		case "msgid":
+			msg.PreviousID = value
+			p.target = &msg.PreviousID
+		case "msgid_plural":
+			p.target = nil
+		case "":
+			if p.target != nil {
+				*p.target += value
+			}
+		default:
+			return fmt.Errorf("unknown previous keyword %q", keyword)
+		}
+	default:
+		msg.Comments = append(msg.Comments, strings.TrimSpace(line[1:]))
+	}
+	return nil
+}
+
+// unquotePO unquotes a PO string, which uses the C escape sequences
+// rather than the Go ones: \' and \? are valid, octal escapes take up to
+// three digits and hex escapes any number of them, as in gettext.
+func unquotePO(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("invalid string %s", s)
+	}
+	var b strings.Builder
+	body := s[1 : len(s)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c == '"' {
+			return "", fmt.Errorf("invalid string %s", s)
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i == len(body) {
+			return "", fmt.Errorf("invalid string %s", s)
+		}
+		switch c = body[i]; c {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'v':
+			b.WriteByte('\v')
+		case 'a':
+			b.WriteByte('\a')
+		case '\\', '"', '\'', '?':
+			b.WriteByte(c)
+		case 'x':
+			j := i + 1
+			for j < len(body) && isHexDigit(body[j]) {
+				j++
+			}
+			if j == i+1 {
+				return "", fmt.Errorf("invalid string %s", s)
+			}
+			n, _ := strconv.ParseUint(body[i+1:j], 16, 64)
+			b.WriteByte(byte(n))
+			i = j - 1
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			j := i
+			for j < len(body) && j < i+3 && body[j] >= '0' && body[j] <= '7' {
+				j++
+			}
+			n, _ := strconv.ParseUint(body[i:j], 8, 16)
+			b.WriteByte(byte(n))
+			i = j - 1
+		default:
+			return "", fmt.Errorf("invalid escape \\%c in string %s", c, s)
+		}
+	}
+	return b.String(), nil
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// WritePO writes a catalog in the PO format, the obsolete entries are
+// written last.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	if err := i18n.WritePO(&buf, catalog); err != nil {
+//		return err
+//	}
+func WritePO(w io.Writer, catalog *types.Catalog) error {
+	bw := bufio.NewWriter(w)
+	first := true
+	write := func(msg *types.Message) {
+		if !first {
+			bw.WriteString("\n")
+		}
+		first = false
+		writeMessage(bw, msg)
+	}
+
+	if catalog.Header != nil {
+		write(catalog.Header)
+	}
+	for _, msg := range catalog.Messages {
+		if !msg.Obsolete {
+			write(msg)
+		}
+	}
+	for _, msg := range catalog.Messages {
+		if msg.Obsolete {
+			write(msg)
+		}
+	}
+	return bw.Flush()
+}
+
+// WritePOFile writes a catalog to path in the PO format.
+//
+// Example:
+//
+//	if err := i18n.WritePOFile("locales/batsignal.pot", template); err != nil {
+//		return err
+//	}
+func WritePOFile(path string, catalog *types.Catalog) error {
+	var buf bytes.Buffer
+	if err := WritePO(&buf, catalog); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// writeMessage writes a single entry.
+func writeMessage(w *bufio.Writer, msg *types.Message) {
+	for _, comment := range msg.Comments {
+		w.WriteString(strings.TrimRight("# "+comment, " ") + "\n")
+	}
+	for _, comment := range msg.ExtractedComments {
+		w.WriteString("#. " + comment + "\n")
+	}
+	writeReferences(w, msg.References)
+	if len(msg.Flags) > 0 {
+		w.WriteString("#, " + strings.Join(msg.Flags, ", ") + "\n")
+	}
+
+	prefix := ""
+	if msg.Obsolete {
+		prefix = "#~ "
+	}
+	if msg.PreviousContext != "" {
+		writeString(w, prefix+"#| ", "msgctxt", msg.PreviousContext)
+	}
+	if msg.PreviousID != "" {
+		writeString(w, prefix+"#| ", "msgid", msg.PreviousID)
+	}
+
+	if msg.Context != "" {
+		writeString(w, prefix, "msgctxt", msg.Context)
+	}
+	writeString(w, prefix, "msgid", msg.ID)
+	if msg.IDPlural == "" {
+		str := ""
+		if len(msg.Str) > 0 {
+			str = msg.Str[0]
+		}
+		writeString(w, prefix, "msgstr", str)
+		return
+	}
+
+	writeString(w, prefix, "msgid_plural", msg.IDPlural)
+	strs := msg.Str
+	if len(strs) == 0 {
+		strs = []string{"", ""}
+	}
+	for i, str := range strs {
+		writeString(w, prefix, fmt.Sprintf("msgstr[%d]", i), str)
+	}
+}
+
+// writeReferences writes the source references, wrapped at 79 columns as
+// xgettext does.
+func writeReferences(w *bufio.Writer, refs []string) {
+	line := ""
+	for _, ref := range refs {
+		if line != "" && len(line)+len(ref)+1 > 79 {
+			w.WriteString(line + "\n")
+			line = ""
+		}
+		if line == "" {
+			line = "#:"
+		}
+		line += " " + ref
+	}
+	if line != "" {
+		w.WriteString(line + "\n")
+	}
+}
+
+// writeString writes a keyword and its value, splitting multi-line values
+// after each newline.
+func writeString(w *bufio.Writer, prefix, keyword, value string) {
+	lines := strings.SplitAfter(value, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= 1 {
+		w.WriteString(prefix + keyword + " " + quotePO(value) + "\n")
+		return
+	}
+
+	w.WriteString(prefix + keyword + ` ""` + "\n")
+	for _, line := range lines {
+		w.WriteString(prefix + quotePO(line) + "\n")
+	}
+}
+
+// quotePO quotes a string using the C escape sequences, keeping non-ASCII
+// characters as they are.
+func quotePO(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
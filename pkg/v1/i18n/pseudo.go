@@ -0,0 +1,185 @@
+package i18n
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Pseudo-localization of strings for i18n testing.
+*/
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/vorlif/spreak"
+	"golang.org/x/text/language"
+)
+
+const (
+	// PseudoLocale is the accented pseudo-locale, as defined by CLDR: the
+	// strings are accented, expanded and wrapped in brackets
+	PseudoLocale = "en_XA"
+
+	// PseudoLocaleRTL is the bidi pseudo-locale, as defined by CLDR: the
+	// strings are wrapped in brackets and mirrored as right-to-left text
+	PseudoLocaleRTL = "ar_XB"
+
+	// PseudoLocaleEnv is the environment variable enabling a pseudo-locale
+	// regardless of the user locale, either PseudoLocale or
+	// PseudoLocaleRTL; "1" selects PseudoLocale
+	PseudoLocaleEnv = "VANILLA_PSEUDO_LOCALE"
+)
+
+// pseudoAccents maps the ASCII letters to their accented form.
+var pseudoAccents = map[rune]rune{
+	'a': 'å', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ',
+	'h': 'ĥ', 'i': 'î', 'j': 'ĵ', 'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ',
+	'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ', 'u': 'û',
+	'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ',
+	'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ',
+	'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ', 'U': 'Û',
+	'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+// pseudoPlaceholderRe matches the parts of a string left untouched: the Go
+// formatting verbs, including the explicit argument indexes, the {name}
+// placeholders and the markup tags.
+var pseudoPlaceholderRe = regexp.MustCompile(`%%|%[-+# 0]*(\[\d+\])?(\d+|\*)?(\.(\d+|\*))?(\[\d+\])?[a-zA-Z]|\{[^{}]*\}|<[^<>]*>`)
+
+// Bidi control characters used by PseudolocalizeRTL.
+const (
+	rlm = "\u200f" // right-to-left mark
+	rlo = "\u202e" // right-to-left override
+	pdf = "\u202c" // pop directional formatting
+)
+
+// Pseudolocalize returns the accented pseudo-translation of a string: the
+// letters are accented, the vowels doubled to expand the text by about 40%,
+// as most translations are longer than English, and the result is wrapped
+// in brackets, so that truncated and untranslated strings stand out.
+// Formatting verbs, {name} placeholders and markup tags are kept as they
+// are.
+//
+// Example:
+//
+//	i18n.Pseudolocalize("Hello %s") // "[Ĥééļļöö %s]"
+func Pseudolocalize(s string) string {
+	return "[" + mapPseudoText(s, func(text string) string {
+		var b strings.Builder
+		for _, r := range text {
+			accented, ok := pseudoAccents[r]
+			if !ok {
+				b.WriteRune(r)
+				continue
+			}
+			b.WriteRune(accented)
+			if strings.ContainsRune("aeiouyAEIOUY", r) {
+				b.WriteRune(accented)
+			}
+		}
+		return b.String()
+	}) + "]"
+}
+
+// PseudolocalizeRTL returns the bidi pseudo-translation of a string: each
+// word is wrapped in right-to-left override characters, so that the text
+// is mirrored when rendered as in a right-to-left language, and the result
+// is wrapped in brackets. Formatting verbs, {name} placeholders and markup
+// tags are kept as they are.
+//
+// Example:
+//
+//	i18n.PseudolocalizeRTL("Hello %s") // "[\u200f\u202eHello\u202c\u200f %s]"
+func PseudolocalizeRTL(s string) string {
+	return "[" + mapPseudoText(s, func(text string) string {
+		var b strings.Builder
+		word := false
+		for _, r := range text {
+			letter := r != ' ' && r != '\n' && r != '\t'
+			if letter && !word {
+				b.WriteString(rlm + rlo)
+			} else if !letter && word {
+				b.WriteString(pdf + rlm)
+			}
+			word = letter
+			b.WriteRune(r)
+		}
+		if word {
+			b.WriteString(pdf + rlm)
+		}
+		return b.String()
+	}) + "]"
+}
+
+// mapPseudoText applies fn to the parts of s which are not placeholders.
+func mapPseudoText(s string, fn func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range pseudoPlaceholderRe.FindAllStringIndex(s, -1) {
+		b.WriteString(fn(s[last:loc[0]]))
+		b.WriteString(s[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(fn(s[last:]))
+	return b.String()
+}
+
+// IsPseudoLocale reports whether a locale is one of the pseudo-locales.
+//
+// Example:
+//
+//	i18n.IsPseudoLocale("en-XA") // true
+func IsPseudoLocale(locale string) bool {
+	normalized := NormalizeLocale(locale)
+	return normalized == PseudoLocale || normalized == PseudoLocaleRTL
+}
+
+// PseudoTranslator returns the pseudo-locale selected by the
+// PseudoLocaleEnv environment variable or, if not set, the first
+// pseudo-locale in a colon separated list of locales, together with the
+// function pseudo-translating the strings. It returns an empty locale and
+// a nil function if no pseudo-locale is selected.
+//
+// Example:
+//
+//	locale, pseudo := i18n.PseudoTranslator("en_XA:it")
+//	if pseudo != nil {
+//		fmt.Println(locale, pseudo("I am Batman!")) // en_XA [ÎÎ ååɱ Ɓååţɱååñ!]
+//	}
+func PseudoTranslator(locale string) (string, func(string) string) {
+	candidates := strings.Split(locale, ":")
+	if env := os.Getenv(PseudoLocaleEnv); env != "" {
+		candidates = []string{env}
+		if env == "1" {
+			candidates = []string{PseudoLocale}
+		}
+	}
+
+	for _, candidate := range candidates {
+		switch NormalizeLocale(candidate) {
+		case PseudoLocale:
+			return PseudoLocale, Pseudolocalize
+		case PseudoLocaleRTL:
+			return PseudoLocaleRTL, PseudolocalizeRTL
+		}
+	}
+	return "", nil
+}
+
+// pseudoPrinter is a spreak printer pseudo-translating the strings before
+// formatting them, so that the formatting verbs are kept.
+type pseudoPrinter struct {
+	spreak.Printer
+	pseudo func(string) string
+}
+
+// GetPrintFunc returns the function printing the strings of a language.
+func (p *pseudoPrinter) GetPrintFunc(lang language.Tag) spreak.PrintFunc {
+	printFunc := p.Printer.GetPrintFunc(lang)
+	return func(str string, vars ...interface{}) string {
+		return printFunc(p.pseudo(str), vars...)
+	}
+}
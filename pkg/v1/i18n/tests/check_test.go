@@ -0,0 +1,155 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/i18n"
+	"github.com/vanilla-os/sdk/pkg/v1/i18n/types"
+)
+
+const englishPO = `msgid ""
+msgstr ""
+"Language: en\n"
+
+msgid "batsignal.cmd.call"
+msgstr "Call Batman"
+
+msgid ""
+"The signal "
+"is on"
+msgstr "The signal is on"
+
+msgid "Calling %s"
+msgstr "Calling %s"
+
+msgid "%d villain spotted"
+msgid_plural "%d villains spotted"
+msgstr[0] "%d villain spotted"
+msgstr[1] "%d villains spotted"
+`
+
+func writeLocales(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"en/LC_MESSAGES/batsignal.po": englishPO,
+		"it/LC_MESSAGES/batsignal.po": italianPO,
+	} {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCheckLocales(t *testing.T) {
+	root := writeSource(t)
+	report, err := i18n.CheckLocales(root, writeLocales(t), types.ExtractOptions{Exclude: []string{"gen"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Strings != 5 || len(report.Locales) != 2 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	en := report.Locales[0]
+	if en.Locale != "en" || en.Translated != 4 || en.Coverage != 80 {
+		t.Errorf("unexpected English report %+v", en)
+	}
+	if len(en.Missing) != 1 || en.Missing[0].ID != "batsignal.msg.done" || en.Missing[0].References[0] != "main.go:15" {
+		t.Errorf("unexpected missing strings %+v", en.Missing)
+	}
+
+	it := report.Locales[1]
+	if it.Locale != "it" || it.Translated != 2 || it.Coverage != 40 || len(it.Missing) != 3 {
+		t.Errorf("unexpected Italian report %+v", it)
+	}
+	if len(it.Unused) != 3 || it.Unused[0].ID != "Calling %s now" || it.Unused[1].Context != "gadget" {
+		t.Errorf("unexpected unused strings %+v", it.Unused)
+	}
+
+	var buf bytes.Buffer
+	if err := i18n.WriteCheckJSON(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	var decoded types.CheckReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || decoded.Locales[1].Coverage != 40 {
+		t.Errorf("unexpected JSON report %s (%v)", buf.String(), err)
+	}
+
+	buf.Reset()
+	if err := i18n.WriteCheckJUnit(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	var junit struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Name  string `xml:"name,attr"`
+			Cases []struct {
+				Name    string    `xml:"name,attr"`
+				Failure *struct{} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &junit); err != nil {
+		t.Fatal(err)
+	}
+	if junit.Failures != 4 || len(junit.Suites) != 2 || junit.Suites[0].Cases[0].Failure == nil {
+		t.Errorf("unexpected JUnit report:\n%s", buf.String())
+	}
+}
+
+func TestCheckFuzzyAndUntranslated(t *testing.T) {
+	template := &types.Catalog{Messages: []*types.Message{
+		{ID: "Robin"}, {ID: "Alfred"}, {ID: "Joker"},
+	}}
+	catalog, err := i18n.ParsePO(strings.NewReader(`#, fuzzy
+msgid "Robin"
+msgstr "Pettirosso"
+
+msgid "Alfred"
+msgstr ""
+
+msgid "Joker"
+msgstr "Joker"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := i18n.CheckCatalog(template, "it", "it.po", catalog)
+	if len(report.Fuzzy) != 1 || report.Fuzzy[0].ID != "Robin" ||
+		len(report.Untranslated) != 1 || report.Untranslated[0].ID != "Alfred" || report.Coverage != 33.3 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestCheckMissingStrings(t *testing.T) {
+	root := writeSource(t)
+	locales := writeLocales(t)
+
+	missing, err := i18n.CheckMissingStrings(root, filepath.Join(locales, "en", "LC_MESSAGES", "batsignal.po"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 2 || missing[filepath.Join(root, "main.go")][0] != "batsignal.msg.done" {
+		t.Errorf("unexpected missing strings %v", missing)
+	}
+}
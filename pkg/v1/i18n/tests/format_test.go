@@ -0,0 +1,139 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vanilla-os/sdk/pkg/v1/i18n"
+	"github.com/vanilla-os/sdk/pkg/v1/i18n/types"
+	"golang.org/x/text/language"
+)
+
+// newFormatter returns the formatter of a locale, failing the test if the
+// locale is not supported.
+func newFormatter(t *testing.T, tag language.Tag) *i18n.Formatter {
+	t.Helper()
+
+	f, err := i18n.NewFormatter(tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestFormatNumbers(t *testing.T) {
+	en := newFormatter(t, language.English)
+	it := newFormatter(t, language.Italian)
+
+	for _, c := range []struct{ got, expected string }{
+		{en.Number(1234567.5), "1,234,567.5"},
+		{it.Number(1234567.5), "1.234.567,5"},
+		{it.Decimal(3.14159, 2), "3,14"},
+		{en.Percent(0.256), "25.6%"},
+		{it.Percent(0.5), "50%"},
+		{en.Size(512), "512 B"},
+		{en.Size(1500000), "1.5 MB"},
+		{it.Size(1500000), "1,5 MB"},
+		{en.SizeIEC(1536), "1.5 KiB"},
+		{newFormatter(t, language.French).Size(2000), "2 ko"},
+	} {
+		if c.got != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, c.got)
+		}
+	}
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	en := newFormatter(t, language.English)
+	de := newFormatter(t, language.German)
+
+	for _, c := range []struct{ got, expected string }{
+		{en.RelativeTimeFrom(now.Add(-3*time.Minute), now), "3 minutes ago"},
+		{en.RelativeTimeFrom(now.Add(time.Hour), now), "in 1 hour"},
+		{en.RelativeTimeFrom(now, now), "now"},
+		{en.RelativeTimeFrom(now.Add(-400*24*time.Hour), now), "1 year ago"},
+		{de.RelativeTimeFrom(now.Add(-2*24*time.Hour), now), "vor 2 Tagen"},
+		{newFormatter(t, language.Italian).RelativeTimeFrom(now.Add(-14*24*time.Hour), now), "2 settimane fa"},
+	} {
+		if c.got != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, c.got)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	en := newFormatter(t, language.English)
+	if got := en.Duration(2*time.Hour + 5*time.Minute); got != "2 hours and 5 minutes" {
+		t.Errorf("unexpected duration %q", got)
+	}
+	if got := en.Duration(26*time.Hour + time.Minute + 1500*time.Millisecond); got != "1 day, 2 hours, 1 minute, and 2 seconds" {
+		t.Errorf("unexpected duration %q", got)
+	}
+	if got := en.Duration(0); got != "0 seconds" {
+		t.Errorf("unexpected duration %q", got)
+	}
+	if got := newFormatter(t, language.German).Duration(48 * time.Hour); got != "2 Tage" {
+		t.Errorf("unexpected duration %q", got)
+	}
+}
+
+func TestFormatDates(t *testing.T) {
+	date := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	en := newFormatter(t, language.AmericanEnglish)
+	it := newFormatter(t, language.Italian)
+	es := newFormatter(t, language.Spanish)
+
+	for _, c := range []struct{ got, expected string }{
+		{en.Date(date, types.DateShort), "1/2/06"},
+		{en.Date(date, types.DateFull), "Monday, January 2, 2006"},
+		{en.Time(date), "3:04 PM"},
+		{newFormatter(t, language.BritishEnglish).Date(date, types.DateLong), "2 January 2006"},
+		{it.Date(date, types.DateShort), "02/01/06"},
+		{it.Date(date, types.DateMedium), "2 gen 2006"},
+		{it.Date(date, types.DateFull), "lunedì 2 gennaio 2006"},
+		{it.Time(date), "15:04"},
+		{es.Date(date, types.DateLong), "2 de enero de 2006"},
+	} {
+		if c.got != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, c.got)
+		}
+	}
+}
+
+func TestFormatList(t *testing.T) {
+	items := []string{"Mirko", "Luca", "Pietro"}
+	for tag, expected := range map[language.Tag]string{
+		language.English: "Mirko, Luca, and Pietro",
+		language.Italian: "Mirko, Luca e Pietro",
+		language.German:  "Mirko, Luca und Pietro",
+	} {
+		if got := newFormatter(t, tag).List(items); got != expected {
+			t.Errorf("expected %q for %s, got %q", expected, tag, got)
+		}
+	}
+	if got := newFormatter(t, language.English).List(items[:2]); got != "Mirko and Luca" {
+		t.Errorf("unexpected list %q", got)
+	}
+}
+
+func TestFormatUnsupportedLocale(t *testing.T) {
+	if _, err := i18n.NewFormatter(language.Japanese); !errors.Is(err, i18n.ErrUnsupportedLocale) {
+		t.Errorf("expected ErrUnsupportedLocale, got %v", err)
+	}
+	if got := newFormatter(t, language.Und).List([]string{"a", "b"}); got != "a and b" {
+		t.Errorf("expected English for an undefined locale, got %q", got)
+	}
+	if got := newFormatter(t, language.MustParse("pt-BR")).Duration(time.Minute); got != "1 minuto" {
+		t.Errorf("expected the data of the language for a regional locale, got %q", got)
+	}
+}
@@ -0,0 +1,288 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/i18n"
+	"github.com/vanilla-os/sdk/pkg/v1/i18n/types"
+)
+
+const batsignalSource = `package main
+
+type RootCmd struct {
+	Call CallCmd ` + "`" + `cmd:"call" help:"pr:batsignal.cmd.call"` + "`" + `
+}
+
+func run(lc Localizer, n int) {
+	// TRANSLATORS: shown when the signal is lit
+	lc.Trans("The signal is on")
+	lc.Trans("Calling " +
+		"%s")
+	lc.NTrans("%d villain spotted", "%d villains spotted", n)
+	lc.Trans("The signal is on")
+	lc.Trans(variable)
+	_ = "pr:batsignal.msg.done"
+}
+`
+
+func writeSource(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"main.go":          batsignalSource,
+		"main_test.go":     "package main\n\nvar _ = \"pr:batsignal.test\"\n",
+		"vendor/dep/a.go":  "package dep\n\nvar _ = \"pr:batsignal.vendor\"\n",
+		"gen/generated.go": "package gen\n\nvar _ = \"pr:batsignal.generated\"\n",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestExtract(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "0")
+	root := writeSource(t)
+
+	template, err := i18n.Extract(root, types.ExtractOptions{
+		Package: "batsignal",
+		Version: "1.0.0",
+		Exclude: []string{"gen"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, msg := range template.Messages {
+		ids = append(ids, msg.ID)
+	}
+	expected := []string{"batsignal.cmd.call", "The signal is on", "Calling %s", "%d villain spotted", "batsignal.msg.done"}
+	if !slices.Equal(ids, expected) {
+		t.Fatalf("expected %q, got %q", expected, ids)
+	}
+
+	on := template.Messages[1]
+	if !slices.Equal(on.References, []string{"main.go:9", "main.go:13"}) {
+		t.Errorf("unexpected references %q", on.References)
+	}
+	if !slices.Equal(on.ExtractedComments, []string{"shown when the signal is lit"}) {
+		t.Errorf("unexpected extracted comments %q", on.ExtractedComments)
+	}
+
+	villains := template.Messages[3]
+	if villains.IDPlural != "%d villains spotted" || !villains.HasFlag("go-format") {
+		t.Errorf("unexpected plural entry %+v", villains)
+	}
+
+	if template.HeaderField("Project-Id-Version") != "batsignal 1.0.0" ||
+		template.HeaderField("POT-Creation-Date") != "1970-01-01 00:00+0000" {
+		t.Errorf("unexpected header %q", template.Header.Str)
+	}
+}
+
+const italianPO = `# Italian translation of batsignal.
+msgid ""
+msgstr ""
+"Language: it\n"
+"Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+# Keep it short
+#: main.go:9
+msgid "The signal is on"
+msgstr "Il segnale è acceso"
+
+#: main.go:12
+#, go-format
+msgid "%d villain spotted"
+msgid_plural "%d villains spotted"
+msgstr[0] "%d cattivo avvistato"
+msgstr[1] "%d cattivi avvistati"
+
+msgid "Calling %s now"
+msgstr "Sto chiamando %s ora"
+
+msgctxt "gadget"
+msgid "Batarang"
+msgstr ""
+"Bata"
+"rang"
+
+msgid "The cave is closed"
+msgstr "La caverna è chiusa"
+`
+
+func TestPORoundTrip(t *testing.T) {
+	catalog, err := i18n.ParsePO(strings.NewReader(italianPO))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(catalog.Messages) != 5 || catalog.HeaderField("Language") != "it" {
+		t.Fatalf("unexpected catalog %+v", catalog)
+	}
+	if batarang := catalog.Find("gadget", "Batarang"); batarang == nil || batarang.Str[0] != "Batarang" {
+		t.Errorf("unexpected context entry %+v", batarang)
+	}
+
+	var buf bytes.Buffer
+	if err := i18n.WritePO(&buf, catalog); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := i18n.ParsePO(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.Messages) != len(catalog.Messages) || parsed.Messages[1].Str[1] != "%d cattivi avvistati" {
+		t.Errorf("unexpected round trip %+v", parsed.Messages)
+	}
+
+	if _, err := i18n.ParsePO(strings.NewReader("msgid \"a\"\nmsgfoo \"b\"\n")); err == nil ||
+		!strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("expected a line error, got %v", err)
+	}
+}
+
+func TestPOEscapes(t *testing.T) {
+	tests := []struct {
+		quoted   string
+		expected string
+	}{
+		{`"Batman\'s car"`, "Batman's car"},
+		{`"Who\?"`, "Who?"},
+		{`"tab\there\n"`, "tab\there\n"},
+		{`"\"quoted\" \\ slash"`, `"quoted" \ slash`},
+		{`"bell\a form\f vtab\v back\b"`, "bell\a form\f vtab\v back\b"},
+		{`"\101\102"`, "AB"},
+		{`"\0101"`, "\b1"},
+		{`"\x42 at"`, "B at"},
+		{`"caffè"`, "caffè"},
+	}
+
+	for _, test := range tests {
+		catalog, err := i18n.ParsePO(strings.NewReader("msgid \"key\"\nmsgstr " + test.quoted + "\n"))
+		if err != nil {
+			t.Errorf("%s: %v", test.quoted, err)
+			continue
+		}
+		if got := catalog.Messages[0].Str[0]; got != test.expected {
+			t.Errorf("%s: got %q, expected %q", test.quoted, got, test.expected)
+		}
+	}
+
+	for _, invalid := range []string{`"\q"`, `"\x"`, `"a"b"`, `"trailing\"`} {
+		if _, err := i18n.ParsePO(strings.NewReader("msgid \"key\"\nmsgstr " + invalid + "\n")); err == nil {
+			t.Errorf("%s: expected an error", invalid)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "0")
+	template, err := i18n.Extract(writeSource(t), types.ExtractOptions{Package: "batsignal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	po, err := i18n.ParsePO(strings.NewReader(italianPO))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged := i18n.Merge(po, template)
+
+	on := merged.Find("", "The signal is on")
+	if on.Str[0] != "Il segnale è acceso" || on.Fuzzy() || !slices.Equal(on.Comments, []string{"Keep it short"}) {
+		t.Errorf("expected the translation to be kept, got %+v", on)
+	}
+	if on.ExtractedComments[0] != "shown when the signal is lit" {
+		t.Errorf("expected the extracted comments of the template, got %q", on.ExtractedComments)
+	}
+
+	calling := merged.Find("", "Calling %s")
+	if !calling.Fuzzy() || calling.Str[0] != "Sto chiamando %s ora" || calling.PreviousID != "Calling %s now" {
+		t.Errorf("expected a fuzzy match, got %+v", calling)
+	}
+
+	if call := merged.Find("", "batsignal.cmd.call"); call.Translated() || len(call.Str) != 1 {
+		t.Errorf("expected a new untranslated entry, got %+v", call)
+	}
+
+	cave := merged.Find("", "The cave is closed")
+	if cave == nil || !cave.Obsolete {
+		t.Errorf("expected an obsolete entry, got %+v", cave)
+	}
+	if batarang := merged.Find("gadget", "Batarang"); batarang == nil || !batarang.Obsolete {
+		t.Errorf("expected an obsolete entry, got %+v", batarang)
+	}
+
+	var buf bytes.Buffer
+	if err := i18n.WritePO(&buf, merged); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, expected := range []string{
+		"#, go-format, fuzzy\n#| msgid \"Calling %s now\"\nmsgid \"Calling %s\"\n",
+		"\n#~ msgid \"The cave is closed\"\n#~ msgstr \"La caverna è chiusa\"\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+
+	reparsed, err := i18n.ParsePO(strings.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cave := reparsed.Find("", "The cave is closed"); cave == nil || !cave.Obsolete {
+		t.Errorf("expected the obsolete entry to be parsed, got %+v", cave)
+	}
+	if calling := reparsed.Find("", "Calling %s"); calling == nil || calling.PreviousID != "Calling %s now" {
+		t.Errorf("expected the previous msgid to be parsed, got %+v", calling)
+	}
+}
+
+func TestUpdateCatalogs(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "0")
+	template, err := i18n.Extract(writeSource(t), types.ExtractOptions{Package: "batsignal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "LINGUAS"), []byte("it de\n"), 0644)
+	os.MkdirAll(filepath.Join(dir, "it", "LC_MESSAGES"), 0755)
+	os.WriteFile(filepath.Join(dir, "it", "LC_MESSAGES", "batsignal.po"), []byte(italianPO), 0644)
+
+	files, err := i18n.UpdateCatalogs(dir, "batsignal", template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("unexpected files %q", files)
+	}
+
+	german, err := i18n.ReadPO(filepath.Join(dir, "de", "LC_MESSAGES", "batsignal.po"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if german.HeaderField("Language") != "de" || german.Header.Fuzzy() || len(german.Messages) != len(template.Messages) {
+		t.Errorf("unexpected new catalog %+v", german)
+	}
+}
@@ -0,0 +1,119 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/vanilla-os/sdk/pkg/v1/i18n"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	for locale, expected := range map[string]string{
+		"it_IT.UTF-8@euro": "it_IT",
+		"pt-br":            "pt_BR",
+		"sr_RS@latin":      "sr_RS@latin",
+		"zh-hant-tw":       "zh_Hant_TW",
+		"C.UTF-8":          "",
+		"POSIX":            "",
+		"de":               "de",
+	} {
+		if normalized := i18n.NormalizeLocale(locale); normalized != expected {
+			t.Errorf("expected %q for %q, got %q", expected, locale, normalized)
+		}
+	}
+
+	if tag := i18n.LocaleTag("sr_RS@latin"); tag.String() != "sr-Latn-RS" {
+		t.Errorf("unexpected tag %s", tag)
+	}
+}
+
+func TestFallbackChain(t *testing.T) {
+	if chain := i18n.FallbackChain("pt_BR.UTF-8"); !slices.Equal(chain, []string{"pt_BR", "pt"}) {
+		t.Errorf("unexpected chain %q", chain)
+	}
+	if chain := i18n.FallbackChain("sr_RS@latin"); !slices.Equal(chain, []string{"sr_RS@latin", "sr@latin", "sr_RS", "sr"}) {
+		t.Errorf("unexpected chain %q", chain)
+	}
+}
+
+func TestUserLocales(t *testing.T) {
+	t.Setenv("LANGUAGE", "pt_BR:it::de_DE.UTF-8")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+	if locales := i18n.UserLocales(); !slices.Equal(locales, []string{"pt_BR", "it", "de_DE", "en_US"}) {
+		t.Errorf("unexpected locales %q", locales)
+	}
+
+	// LANGUAGE is ignored for the C locale
+	t.Setenv("LC_ALL", "C")
+	if locales := i18n.UserLocales(); len(locales) != 0 {
+		t.Errorf("unexpected locales %q", locales)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	available := []string{"en", "it", "pt", "sr@latin", "zh_TW"}
+	for _, test := range []struct {
+		preferred []string
+		expected  string
+	}{
+		{[]string{"pt_BR"}, "pt"},
+		{[]string{"fr", "it_IT.UTF-8@euro"}, "it"},
+		{[]string{"sr_RS@latin"}, "sr@latin"},
+		{[]string{"zh"}, "zh_TW"},
+		{[]string{"fr"}, ""},
+	} {
+		if locale := i18n.Negotiate(available, test.preferred); locale != test.expected {
+			t.Errorf("expected %q for %q, got %q", test.expected, test.preferred, locale)
+		}
+	}
+}
+
+func TestNewLocalizerFallback(t *testing.T) {
+	locales := fstest.MapFS{
+		"LINGUAS": {Data: []byte("pt it\n")},
+		"pt/LC_MESSAGES/batsignal.po": {Data: []byte(`msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: pt\n"
+
+msgid "I am Batman!"
+msgstr "Eu sou o Batman!"
+`)},
+		"it/LC_MESSAGES/batsignal.po": {Data: []byte(`msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: it\n"
+
+msgid "I am Batman!"
+msgstr "Io sono Batman!"
+`)},
+	}
+
+	lc, err := i18n.NewLocalizer(locales, "batsignal", "fr_FR:pt_BR.UTF-8:it")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := lc.Get("I am Batman!"); msg != "Eu sou o Batman!" {
+		t.Errorf("expected the Portuguese translation, got %q", msg)
+	}
+
+	// no catalog matches and there is no English one
+	lc, err = i18n.NewLocalizer(locales, "batsignal", "fr_FR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := lc.Get("I am Batman!"); msg != "I am Batman!" {
+		t.Errorf("expected the untranslated string, got %q", msg)
+	}
+}
@@ -0,0 +1,94 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/vanilla-os/sdk/pkg/v1/i18n"
+)
+
+func TestPseudolocalize(t *testing.T) {
+	for s, expected := range map[string]string{
+		"Hello %s":                    "[Ĥééļļöö %s]",
+		"%[1]d items, 100%%":          "[%[1]d îîţééɱš, 100%%]",
+		"Open {file} in <b>Files</b>": "[ÖÖþééñ {file} îîñ <b>Ƒîîļééš</b>]",
+	} {
+		if got := i18n.Pseudolocalize(s); got != expected {
+			t.Errorf("expected %q for %q, got %q", expected, s, got)
+		}
+	}
+
+	if got := i18n.PseudolocalizeRTL("Hi %s"); got != "[\u200f\u202eHi\u202c\u200f %s]" {
+		t.Errorf("unexpected RTL pseudo-translation %q", got)
+	}
+}
+
+func TestPseudoTranslator(t *testing.T) {
+	t.Setenv(i18n.PseudoLocaleEnv, "")
+
+	if locale, pseudo := i18n.PseudoTranslator("it:en-XA"); locale != i18n.PseudoLocale || pseudo == nil {
+		t.Errorf("expected the accented pseudo-locale, got %q", locale)
+	}
+	if locale, pseudo := i18n.PseudoTranslator("it_IT.UTF-8"); locale != "" || pseudo != nil {
+		t.Errorf("expected no pseudo-locale, got %q", locale)
+	}
+
+	t.Setenv(i18n.PseudoLocaleEnv, "ar_XB")
+	if locale, _ := i18n.PseudoTranslator("it"); locale != i18n.PseudoLocaleRTL {
+		t.Errorf("expected the environment to select the RTL pseudo-locale, got %q", locale)
+	}
+	if !i18n.IsPseudoLocale("ar-XB") || i18n.IsPseudoLocale("ar") {
+		t.Error("unexpected pseudo-locale detection")
+	}
+}
+
+func TestNewPseudoLocalizer(t *testing.T) {
+	t.Setenv(i18n.PseudoLocaleEnv, "")
+	locales := fstest.MapFS{
+		"LINGUAS": {Data: []byte("en\n")},
+		"en/LC_MESSAGES/batsignal.po": {Data: []byte(`msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: en\n"
+"Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+msgid "Bat-signal"
+msgstr "Bat signal"
+`)},
+	}
+
+	lc, err := i18n.NewPseudoLocalizer(locales, "batsignal", i18n.PseudoLocale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := lc.Get("Bat-signal"); msg != "[Ɓååţ šîîĝñååļ]" {
+		t.Errorf("expected the pseudo-translated English string, got %q", msg)
+	}
+	if msg := lc.Getf("Hello %s", "Bruce"); msg != "[Ĥééļļöö Bruce]" {
+		t.Errorf("expected the untranslated string pseudo-translated, got %q", msg)
+	}
+	if msg := lc.NGet("%d gadget", "%d gadgets", 2); msg != "[%d ĝååðĝééţš]" {
+		t.Errorf("unexpected plural pseudo-translation %q", msg)
+	}
+
+	// the source strings are pseudo-translated without catalogs
+	lc, err = i18n.NewPseudoLocalizer(nil, "batsignal", i18n.PseudoLocaleRTL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg := lc.Get("Hi"); msg != "[\u200f\u202eHi\u202c\u200f]" {
+		t.Errorf("unexpected RTL pseudo-translation %q", msg)
+	}
+
+	if _, err := i18n.NewPseudoLocalizer(locales, "batsignal", "it"); err == nil {
+		t.Error("expected an error without a pseudo-locale")
+	}
+}
@@ -0,0 +1,64 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+// CheckEntry is a string reported by the check of the catalogs.
+type CheckEntry struct {
+	// Context is the msgctxt of the string, if any
+	Context string `json:"context,omitempty"`
+
+	// ID is the msgid of the string
+	ID string `json:"id"`
+
+	// References lists where the string is used in the source code
+	References []string `json:"references,omitempty"`
+}
+
+// LocaleReport is the result of the check of the catalog of a locale.
+type LocaleReport struct {
+	// Locale is the locale of the catalog, e.g. "it"
+	Locale string `json:"locale"`
+
+	// File is the path of the catalog
+	File string `json:"file"`
+
+	// Total is the number of strings used in the source code
+	Total int `json:"total"`
+
+	// Translated is the number of strings translated and not fuzzy
+	Translated int `json:"translated"`
+
+	// Coverage is the percentage of translated strings
+	Coverage float64 `json:"coverage"`
+
+	// Missing lists the strings used in the source code but not found in
+	// the catalog
+	Missing []CheckEntry `json:"missing"`
+
+	// Untranslated lists the strings found in the catalog without a
+	// translation
+	Untranslated []CheckEntry `json:"untranslated"`
+
+	// Fuzzy lists the strings whose translation needs to be reviewed
+	Fuzzy []CheckEntry `json:"fuzzy"`
+
+	// Unused lists the strings of the catalog no longer used in the
+	// source code, obsolete entries excluded
+	Unused []CheckEntry `json:"unused"`
+}
+
+// CheckReport is the result of the check of the catalogs of a project.
+type CheckReport struct {
+	// Strings is the number of translatable strings found in the source
+	// code
+	Strings int `json:"strings"`
+
+	// Locales holds a report per catalog, sorted by locale
+	Locales []LocaleReport `json:"locales"`
+}
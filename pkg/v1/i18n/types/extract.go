@@ -0,0 +1,30 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+// ExtractOptions configures the extraction of translatable strings from
+// the source code.
+type ExtractOptions struct {
+	// Package is the name of the package, written in the
+	// Project-Id-Version field of the template
+	Package string
+
+	// Version is the version of the package
+	Version string
+
+	// BugsAddress is written in the Report-Msgid-Bugs-To field of the
+	// template
+	BugsAddress string
+
+	// Exclude lists the directories and files to skip, as names (e.g.
+	// "examples") or glob patterns matched against the slash separated
+	// path relative to the root (e.g. "internal/*_gen.go"). Hidden
+	// directories, vendor, testdata and test files are always skipped
+	Exclude []string
+}
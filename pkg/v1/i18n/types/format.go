@@ -0,0 +1,26 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+// DateStyle is the length of a formatted date, as defined by CLDR.
+type DateStyle int
+
+const (
+	// DateShort is the numeric form, e.g. 1/2/06
+	DateShort DateStyle = iota
+
+	// DateMedium uses the abbreviated month name, e.g. Jan 2, 2006
+	DateMedium
+
+	// DateLong uses the full month name, e.g. January 2, 2006
+	DateLong
+
+	// DateFull adds the weekday, e.g. Monday, January 2, 2006
+	DateFull
+)
@@ -0,0 +1,168 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"slices"
+	"strings"
+)
+
+// Message is an entry of a PO catalog.
+type Message struct {
+	// Context is the msgctxt of the entry, used to disambiguate
+	// identical strings
+	Context string
+
+	// ID is the msgid of the entry
+	ID string
+
+	// IDPlural is the msgid_plural of the entry, empty for singular
+	// entries
+	IDPlural string
+
+	// Str holds the translations, a single one for singular entries and
+	// one per plural form otherwise
+	Str []string
+
+	// Comments holds the translator comments ("# ")
+	Comments []string
+
+	// ExtractedComments holds the comments extracted from the source code
+	// ("#.")
+	ExtractedComments []string
+
+	// References holds the source references ("#:"), e.g. "cmd/root.go:42"
+	References []string
+
+	// Flags holds the flags of the entry ("#,"), e.g. "fuzzy" or
+	// "go-format"
+	Flags []string
+
+	// PreviousContext is the msgctxt the entry had before being marked as
+	// fuzzy ("#| msgctxt")
+	PreviousContext string
+
+	// PreviousID is the msgid the entry had before being marked as fuzzy
+	// ("#| msgid")
+	PreviousID string
+
+	// Obsolete reports whether the entry is no longer used in the source
+	// code ("#~")
+	Obsolete bool
+}
+
+// Key returns a key identifying the entry by its context and msgid, as
+// gettext does in MO files.
+func (m *Message) Key() string {
+	if m.Context == "" {
+		return m.ID
+	}
+	return m.Context + "\x04" + m.ID
+}
+
+// HasFlag reports whether the entry has the given flag.
+func (m *Message) HasFlag(flag string) bool {
+	return slices.Contains(m.Flags, flag)
+}
+
+// AddFlag adds a flag to the entry, if not already present.
+func (m *Message) AddFlag(flag string) {
+	if !m.HasFlag(flag) {
+		m.Flags = append(m.Flags, flag)
+	}
+}
+
+// RemoveFlag removes a flag from the entry.
+func (m *Message) RemoveFlag(flag string) {
+	m.Flags = slices.DeleteFunc(m.Flags, func(f string) bool {
+		return f == flag
+	})
+}
+
+// Fuzzy reports whether the translation of the entry needs to be reviewed.
+func (m *Message) Fuzzy() bool {
+	return m.HasFlag("fuzzy")
+}
+
+// Translated reports whether all the translations of the entry are set.
+func (m *Message) Translated() bool {
+	if len(m.Str) == 0 {
+		return false
+	}
+	for _, str := range m.Str {
+		if str == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Catalog is a PO or POT file.
+type Catalog struct {
+	// Header is the entry with an empty msgid holding the metadata of the
+	// catalog, nil if missing
+	Header *Message
+
+	// Messages holds the entries of the catalog, in file order
+	Messages []*Message
+}
+
+// HeaderField returns the value of a field of the header, e.g.
+// "Plural-Forms", or an empty string.
+func (c *Catalog) HeaderField(name string) string {
+	if c.Header == nil || len(c.Header.Str) == 0 {
+		return ""
+	}
+	for _, line := range strings.Split(c.Header.Str[0], "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// SetHeaderField sets the value of a field of the header, creating the
+// header if needed and appending the field if not already present.
+func (c *Catalog) SetHeaderField(name, value string) {
+	if c.Header == nil {
+		c.Header = &Message{}
+	}
+	if len(c.Header.Str) == 0 {
+		c.Header.Str = []string{""}
+	}
+
+	lines := strings.Split(strings.TrimSuffix(c.Header.Str[0], "\n"), "\n")
+	if lines[0] == "" {
+		lines = lines[:0]
+	}
+	found := false
+	for i, line := range lines {
+		key, _, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			lines[i] = name + ": " + value
+			found = true
+		}
+	}
+	if !found {
+		lines = append(lines, name+": "+value)
+	}
+	c.Header.Str[0] = strings.Join(lines, "\n") + "\n"
+}
+
+// Find returns the entry with the given context and msgid, including the
+// obsolete ones, or nil.
+func (c *Catalog) Find(context, id string) *Message {
+	for _, msg := range c.Messages {
+		if msg.Context == context && msg.ID == id {
+			return msg
+		}
+	}
+	return nil
+}
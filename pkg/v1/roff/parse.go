@@ -0,0 +1,449 @@
+package roff
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Parser for the roff subset emitted by Document.
+*/
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vanilla-os/sdk/pkg/v1/roff/types"
+)
+
+// parser holds the state while reading a roff source.
+type parser struct {
+	lines []string
+	pos   int
+
+	root *types.Node
+
+	// containers is the stack of nodes receiving blocks, the last one is
+	// the current container
+	containers []*types.Node
+
+	// current is the open block receiving text lines
+	current   *types.Node
+	expectTag bool
+	bold      bool
+	italic    bool
+}
+
+// Parse parses the roff subset emitted by Document, i.e. the man(7) macros
+// plus tbl tables, into a tree of nodes. Unsupported macros result in an
+// error reporting their line.
+//
+// Example:
+//
+//	man, _ := cli.GenerateManPage(&RootCmd{}, nil)
+//	doc, err := roff.Parse(man)
+//	if err != nil {
+//		return err
+//	}
+//	for _, section := range doc.Children {
+//		fmt.Println(section.Text)
+//	}
+func Parse(src string) (*types.Node, error) {
+	root := &types.Node{Kind: types.NodeDocument}
+	p := &parser{
+		lines:      strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n"),
+		root:       root,
+		containers: []*types.Node{root},
+	}
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		p.pos++
+
+		if !isControlLine(line) {
+			p.text(line)
+			continue
+		}
+
+		name, args := splitMacro(line[1:])
+		if err := p.macro(name, args); err != nil {
+			return nil, fmt.Errorf("line %d: %w", p.pos, err)
+		}
+	}
+
+	return root, nil
+}
+
+func isControlLine(line string) bool {
+	return strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'")
+}
+
+// macro handles a control line.
+func (p *parser) macro(name string, args []string) error {
+	switch name {
+	case "", `\"`:
+		// empty requests and comments
+	case "TH":
+		p.root.Args = args
+	case "SH":
+		p.close()
+		section := &types.Node{Kind: types.NodeSection, Text: strings.Join(args, " ")}
+		p.root.Children = append(p.root.Children, section)
+		p.containers = []*types.Node{p.root, section}
+	case "SS":
+		p.close()
+		p.append(&types.Node{Kind: types.NodeSubSection, Text: strings.Join(args, " ")})
+	case "PP", "LP", "P":
+		p.close()
+	case "RS":
+		p.close()
+		indent := &types.Node{Kind: types.NodeIndent}
+		p.append(indent)
+		p.containers = append(p.containers, indent)
+	case "RE":
+		p.close()
+		if top := p.containers[len(p.containers)-1]; top.Kind == types.NodeIndent {
+			p.containers = p.containers[:len(p.containers)-1]
+		}
+	case "TP":
+		p.close()
+		p.current = &types.Node{Kind: types.NodeTagged}
+		p.append(p.current)
+		p.expectTag = true
+	case "IP":
+		p.close()
+		p.current = &types.Node{Kind: types.NodeListItem}
+		if len(args) > 0 {
+			p.current.Text = spansText(parseSpans(args[0], new(bool), new(bool)))
+		}
+		p.append(p.current)
+	case "EX":
+		p.close()
+		lines, err := p.until("EE")
+		if err != nil {
+			return err
+		}
+		for i, line := range lines {
+			lines[i] = unescape(line)
+		}
+		p.append(&types.Node{Kind: types.NodeExample, Lines: lines})
+	case "SY":
+		p.close()
+		lines, err := p.until("YS")
+		if err != nil {
+			return err
+		}
+		node := &types.Node{Kind: types.NodeSynopsis}
+		if len(args) > 0 {
+			node.Text = unescape(args[0])
+		}
+		for _, line := range lines {
+			node.Lines = append(node.Lines, unescape(line))
+		}
+		p.append(node)
+	case "UR":
+		p.close()
+		lines, err := p.until("UE")
+		if err != nil {
+			return err
+		}
+		node := &types.Node{Kind: types.NodeLink}
+		if len(args) > 0 {
+			node.Text = args[0]
+		}
+		node.Spans = parseSpans(strings.Join(lines, " "), &p.bold, &p.italic)
+		p.append(node)
+	case "TS":
+		p.close()
+		lines, err := p.until("TE")
+		if err != nil {
+			return err
+		}
+		p.append(parseTable(lines))
+	case "B", "I":
+		font := types.Span{Text: unescape(strings.Join(args, " ")), Bold: name == "B", Italic: name == "I"}
+		p.spans([]types.Span{font})
+	case "BR", "RB", "BI", "IB", "IR", "RI":
+		var spans []types.Span
+		for i, arg := range args {
+			font := name[i%2]
+			spans = append(spans, types.Span{Text: unescape(arg), Bold: font == 'B', Italic: font == 'I'})
+		}
+		p.spans(spans)
+	default:
+		return fmt.Errorf("unsupported macro .%s", name)
+	}
+	return nil
+}
+
+// until returns the lines up to the closing macro, which is consumed.
+func (p *parser) until(end string) ([]string, error) {
+	var lines []string
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		p.pos++
+		if isControlLine(line) {
+			if name, _ := splitMacro(line[1:]); name == end {
+				return lines, nil
+			}
+		}
+		lines = append(lines, line)
+	}
+	return nil, fmt.Errorf("missing .%s", end)
+}
+
+// append adds a block to the current container.
+func (p *parser) append(node *types.Node) {
+	top := p.containers[len(p.containers)-1]
+	top.Children = append(top.Children, node)
+}
+
+// close ends the open text block.
+func (p *parser) close() {
+	p.current = nil
+	p.expectTag = false
+}
+
+// text handles a text line.
+func (p *parser) text(line string) {
+	if strings.TrimSpace(line) == "" {
+		p.close()
+		return
+	}
+	p.spans(parseSpans(line, &p.bold, &p.italic))
+}
+
+// spans adds spans to the open text block, opening a paragraph if needed.
+func (p *parser) spans(spans []types.Span) {
+	if p.expectTag && p.current != nil {
+		p.current.Tag = append(p.current.Tag, spans...)
+		p.expectTag = false
+		return
+	}
+
+	if p.current == nil {
+		p.current = &types.Node{Kind: types.NodeParagraph}
+		p.append(p.current)
+	}
+	if len(p.current.Spans) > 0 && len(spans) > 0 {
+		// filled lines are joined by a space, in the font of the new line
+		spans[0].Text = " " + spans[0].Text
+	}
+	p.current.Spans = mergeSpans(append(p.current.Spans, spans...))
+}
+
+// splitMacro splits a control line into the macro name and its arguments,
+// handling quoted arguments.
+func splitMacro(line string) (string, []string) {
+	line = strings.TrimLeft(line, " \t")
+	if strings.HasPrefix(line, `\"`) {
+		return `\"`, nil
+	}
+
+	name, rest, _ := strings.Cut(line, " ")
+	var args []string
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			break
+		}
+
+		if rest[0] != '"' {
+			var arg string
+			arg, rest, _ = strings.Cut(rest, " ")
+			args = append(args, arg)
+			continue
+		}
+
+		// quoted argument, "" is an escaped quote
+		var b strings.Builder
+		i := 1
+		for ; i < len(rest); i++ {
+			if rest[i] == '"' {
+				if i+1 < len(rest) && rest[i+1] == '"' {
+					b.WriteByte('"')
+					i++
+					continue
+				}
+				break
+			}
+			b.WriteByte(rest[i])
+		}
+		args = append(args, b.String())
+		if i+1 < len(rest) {
+			rest = rest[i+1:]
+		} else {
+			rest = ""
+		}
+	}
+	return name, args
+}
+
+// parseSpans splits a text line into spans, applying the font escapes.
+// The font state is shared across lines.
+func parseSpans(line string, bold, italic *bool) []types.Span {
+	var spans []types.Span
+	var b strings.Builder
+
+	flush := func() {
+		if b.Len() > 0 {
+			spans = append(spans, types.Span{Text: b.String(), Bold: *bold, Italic: *italic})
+			b.Reset()
+		}
+	}
+
+	for i := 0; i < len(line); i++ {
+		if line[i] != '\\' || i+1 >= len(line) {
+			b.WriteByte(line[i])
+			continue
+		}
+
+		i++
+		if line[i] == 'f' && i+1 < len(line) {
+			i++
+			flush()
+			switch line[i] {
+			case 'B':
+				*bold, *italic = true, false
+			case 'I':
+				*bold, *italic = false, true
+			default:
+				*bold, *italic = false, false
+			}
+			continue
+		}
+
+		text, size := escape(line[i:])
+		b.WriteString(text)
+		i += size - 1
+	}
+	flush()
+
+	return mergeSpans(spans)
+}
+
+// escape resolves the escape sequence at the beginning of s, without the
+// leading backslash, returning its text and the number of bytes read.
+func escape(s string) (string, int) {
+	switch s[0] {
+	case 'e', '\\':
+		return `\`, 1
+	case '&', ')':
+		return "", 1
+	case '-':
+		return "-", 1
+	case ' ', '~':
+		return " ", 1
+	case '(':
+		if len(s) >= 3 {
+			switch s[1:3] {
+			case "bu":
+				return "•", 3
+			case "em":
+				return "—", 3
+			case "en":
+				return "–", 3
+			case "aq":
+				return "'", 3
+			case "dq":
+				return `"`, 3
+			}
+			return "", 3
+		}
+	}
+	return s[:1], 1
+}
+
+// unescape resolves the escapes of a verbatim line, ignoring font changes.
+func unescape(s string) string {
+	return spansText(parseSpans(s, new(bool), new(bool)))
+}
+
+func spansText(spans []types.Span) string {
+	return types.PlainText(spans)
+}
+
+// mergeSpans joins consecutive spans sharing the same font.
+func mergeSpans(spans []types.Span) []types.Span {
+	var merged []types.Span
+	for _, span := range spans {
+		if span.Text == "" {
+			continue
+		}
+		if n := len(merged); n > 0 && merged[n-1].Bold == span.Bold && merged[n-1].Italic == span.Italic {
+			merged[n-1].Text += span.Text
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
+
+// parseTable parses the lines of a tbl table: the options, the format
+// lines ending with a dot and the data rows.
+func parseTable(lines []string) *types.Node {
+	node := &types.Node{Kind: types.NodeTable}
+	sep := "\t"
+
+	i := 0
+	if i < len(lines) && strings.HasSuffix(strings.TrimSpace(lines[i]), ";") {
+		options := lines[i]
+		if start := strings.Index(options, "tab("); start >= 0 && start+5 < len(options) {
+			sep = options[start+4 : start+5]
+		}
+		i++
+	}
+
+	var formats []string
+	for ; i < len(lines); i++ {
+		format := strings.TrimSpace(lines[i])
+		formats = append(formats, format)
+		if strings.HasSuffix(format, ".") {
+			i++
+			break
+		}
+	}
+	if len(formats) > 1 {
+		node.Header = true
+		for _, column := range strings.Fields(formats[0]) {
+			if !strings.Contains(column, "b") {
+				node.Header = false
+			}
+		}
+	}
+
+	// T{ and T} delimit text blocks spanning multiple lines
+	var row []string
+	var block *strings.Builder
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if block != nil {
+			if strings.HasPrefix(line, "T}") {
+				row = append(row, unescape(strings.TrimSpace(block.String())))
+				block = nil
+				line = strings.TrimPrefix(strings.TrimPrefix(line, "T}"), sep)
+				if line == "" {
+					node.Rows = append(node.Rows, row)
+					row = nil
+					continue
+				}
+			} else {
+				block.WriteString(line + " ")
+				continue
+			}
+		}
+
+		cells := strings.Split(line, sep)
+		for j, cell := range cells {
+			if cell == "T{" && j == len(cells)-1 {
+				block = &strings.Builder{}
+				break
+			}
+			row = append(row, unescape(cell))
+		}
+		if block == nil {
+			node.Rows = append(node.Rows, row)
+			row = nil
+		}
+	}
+	return node
+}
@@ -28,7 +28,8 @@ func NewDocument() *Document {
 }
 
 func (d *Document) writef(format string, args ...any) {
-	if bytes.HasSuffix(d.Buffer.Bytes(), []byte("\n")) && strings.HasPrefix(format, "\n") {
+	atLineStart := d.Buffer.Len() == 0 || bytes.HasSuffix(d.Buffer.Bytes(), []byte("\n"))
+	if atLineStart && strings.HasPrefix(format, "\n") {
 		format = strings.TrimPrefix(format, "\n")
 	}
 	fmt.Fprintf(&d.Buffer, format, args...)
@@ -82,6 +83,17 @@ func (d *Document) SubSection(text string) {
 	d.writelnf(types.SubSectionHeading, strings.ToUpper(text))
 }
 
+// SubSectionTitle writes a subsection heading keeping the case of the
+// text, use it for headings that must match what the user types, such as
+// command names.
+//
+// Example:
+//
+//	d.SubSectionTitle("batctl use")
+func (d *Document) SubSectionTitle(text string) {
+	d.writelnf(types.SubSectionHeading, text)
+}
+
 // EndSection ends the current section.
 func (d *Document) EndSection() { d.writelnf("") }
 
@@ -106,7 +118,7 @@ func (d *Document) Text(text string) {
 				d.IndentEnd()
 				inList = false
 			}
-			d.writef(escapeText(line))
+			d.writef("%s", escapeText(line))
 		}
 	}
 }
@@ -125,11 +137,191 @@ func (d *Document) TextItalic(text string) {
 	d.writef(types.PreviousFont)
 }
 
+// Name writes the NAME section, in the "name \- description" form
+// expected by man page indexers such as mandb and apropos.
+//
+// Example:
+//
+//	d.Name("batctl", "control the batmobile")
+func (d *Document) Name(name, desc string) {
+	d.LocalizedName(types.SectionName, name, desc)
+}
+
+// LocalizedName writes the NAME section like Name, using a translated
+// heading such as "NOME" or "BEZEICHNUNG", which mandb recognizes as well.
+//
+// Example:
+//
+//	d.LocalizedName("NOME", "batctl", "controlla la batmobile")
+func (d *Document) LocalizedName(heading, name, desc string) {
+	d.Section(heading)
+	if desc == "" {
+		d.writelnf("%s", escapeText(name))
+		return
+	}
+	d.writelnf("%s \\- %s", escapeText(name), escapeText(desc))
+}
+
+// Synopsis writes the synopsis of a command, the command name is printed
+// in bold and each argument on its own line.
+//
+// Example:
+//
+//	d.Synopsis("batctl use", "[flags]", "<gadget>")
+func (d *Document) Synopsis(command string, args ...string) {
+	d.writelnf(types.SynopsisStart+" %s", quoteArg(command))
+	for _, arg := range args {
+		d.writelnf("%s", escapeText(arg))
+	}
+	d.writelnf(types.SynopsisEnd)
+}
+
+// Example writes a block of lines printed verbatim in a monospaced font,
+// such as a shell session.
+//
+// Example:
+//
+//	d.Example("$ batctl use batarang", "Batarang thrown")
+func (d *Document) Example(lines ...string) {
+	d.writelnf(types.ExampleStart)
+	for _, line := range lines {
+		d.writelnf("%s", escapeLine(line))
+	}
+	d.writelnf(types.ExampleEnd)
+}
+
+// Link writes a hyperlink, if text is empty the URL itself is shown.
+//
+// Example:
+//
+//	d.Link("https://vanillaos.org", "Vanilla OS website")
+func (d *Document) Link(url, text string) {
+	d.writelnf(types.URLStart+" %s", quoteArg(url))
+	if text != "" {
+		d.writelnf("%s", escapeText(text))
+	}
+	d.writelnf(types.URLEnd)
+}
+
+// Table writes a tbl table with a bold header row. The last column expands
+// to the available width and long cells are wrapped.
+//
+// Example:
+//
+//	d.Table(
+//		[]string{"Code", "Meaning"},
+//		[][]string{{"0", "Success"}, {"1", "Failure"}},
+//	)
+func (d *Document) Table(headers []string, rows [][]string) {
+	columns := len(headers)
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	if columns == 0 {
+		return
+	}
+
+	d.writelnf(types.TableStart)
+	d.writelnf("tab(\t);")
+	if len(headers) > 0 {
+		d.writelnf("%s", strings.TrimSpace(strings.Repeat("lb ", columns)))
+	}
+	d.writelnf("%slx.", strings.Repeat("l ", columns-1))
+
+	if len(headers) > 0 {
+		d.writelnf("%s", tableRow(headers, columns))
+	}
+	for _, row := range rows {
+		d.writelnf("%s", tableRow(row, columns))
+	}
+	d.writelnf(types.TableEnd)
+}
+
+// References writes a comma separated list of references to other man
+// pages, typically in the SEE ALSO section.
+//
+// Example:
+//
+//	d.References(types.Reference{Name: "apx", Section: "1"})
+func (d *Document) References(refs ...types.Reference) {
+	for i, ref := range refs {
+		suffix := "(" + ref.Section + ")"
+		if i < len(refs)-1 {
+			suffix += ","
+		}
+		d.writelnf(types.BoldRoman+" %s %s", quoteArg(ref.Name), quoteArg(suffix))
+	}
+}
+
+// ParseReference parses a man page reference in the "name(section)" form.
+//
+// Example:
+//
+//	ref, ok := roff.ParseReference("apx(1)")
+func ParseReference(s string) (types.Reference, bool) {
+	s = strings.TrimSpace(s)
+	open := strings.LastIndex(s, "(")
+	if open <= 0 || !strings.HasSuffix(s, ")") || open == len(s)-2 {
+		return types.Reference{}, false
+	}
+	name, section := s[:open], s[open+1:len(s)-1]
+	if strings.ContainsAny(name, " /") || strings.ContainsAny(section, " ()") {
+		return types.Reference{}, false
+	}
+	return types.Reference{Name: name, Section: section}, true
+}
+
 // String returns the document as a string.
 func (d *Document) String() string { return d.Buffer.String() }
 
 func escapeText(s string) string {
 	s = strings.ReplaceAll(s, `\`, `\e`)
 	s = strings.ReplaceAll(s, ".", "\\&.")
+	if strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
 	return s
 }
+
+// escapeLine escapes a line printed verbatim, only backslashes and the
+// control characters at the beginning of the line need to be escaped.
+func escapeLine(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+// quoteArg quotes a macro argument containing spaces.
+func quoteArg(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	if strings.ContainsAny(s, " \t") || s == "" {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+	return s
+}
+
+// tableRow formats a tbl row, wrapping long cells in text blocks.
+func tableRow(cells []string, columns int) string {
+	row := make([]string, columns)
+	for i := range row {
+		if i >= len(cells) {
+			continue
+		}
+		lines := strings.Split(strings.ReplaceAll(cells[i], "\t", " "), "\n")
+		for j, line := range lines {
+			lines[j] = escapeLine(line)
+		}
+		cell := strings.Join(lines, "\n")
+		switch {
+		case len(cell) > 40 || len(lines) > 1:
+			cell = "T{\n" + cell + "\nT}"
+		case cell == "_" || cell == "=" || cell == "^":
+			// tbl reads these cells as rules and vertical spans
+			cell = `\&` + cell
+		}
+		row[i] = cell
+	}
+	return strings.Join(row, "\t")
+}
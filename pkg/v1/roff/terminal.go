@@ -0,0 +1,288 @@
+package roff
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Terminal renderer for parsed roff documents.
+*/
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vanilla-os/sdk/pkg/v1/roff/types"
+)
+
+const (
+	// terminalIndent is the indentation of the section content
+	terminalIndent = 4
+
+	// terminalTagIndent is the indentation of tagged paragraph bodies,
+	// relative to the tag
+	terminalTagIndent = 4
+)
+
+var (
+	headingStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	subHeadingStyle = lipgloss.NewStyle().Bold(true)
+	boldStyle       = lipgloss.NewStyle().Bold(true)
+	italicStyle     = lipgloss.NewStyle().Underline(true)
+	exampleStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	linkStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Underline(true)
+)
+
+// RenderTerminal renders a parsed document for the terminal, wrapping the
+// text at the given width. Styles are only applied if the standard output
+// supports them, so the result can be printed as is.
+//
+// Example:
+//
+//	doc, err := roff.Parse(man)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(roff.RenderTerminal(doc, 80))
+func RenderTerminal(doc *types.Node, width int) string {
+	if width < 20 {
+		width = 20
+	}
+
+	r := &terminalRenderer{width: width}
+	r.title(doc.Args)
+	for _, node := range doc.Children {
+		r.block(node, 0)
+	}
+	return strings.Join(r.lines, "\n") + "\n"
+}
+
+// terminalRenderer accumulates the rendered lines.
+type terminalRenderer struct {
+	width int
+	lines []string
+}
+
+// title writes the header line, e.g. "MYAPP(1)   General Commands   MYAPP(1)".
+func (r *terminalRenderer) title(args []string) {
+	if len(args) == 0 {
+		return
+	}
+
+	name := args[0]
+	if len(args) > 1 {
+		name += "(" + args[1] + ")"
+	}
+	center := ""
+	if len(args) > 4 {
+		center = args[4]
+	}
+
+	gap := r.width - 2*lipgloss.Width(name) - lipgloss.Width(center)
+	if gap < 2 {
+		r.lines = append(r.lines, boldStyle.Render(name))
+		return
+	}
+	left := gap / 2
+	r.lines = append(r.lines, boldStyle.Render(name)+strings.Repeat(" ", left)+center+strings.Repeat(" ", gap-left)+boldStyle.Render(name))
+}
+
+// blank separates two blocks with an empty line.
+func (r *terminalRenderer) blank() {
+	if n := len(r.lines); n > 0 && r.lines[n-1] != "" {
+		r.lines = append(r.lines, "")
+	}
+}
+
+// block renders a node at the given indentation.
+func (r *terminalRenderer) block(node *types.Node, indent int) {
+	pad := strings.Repeat(" ", indent)
+
+	switch node.Kind {
+	case types.NodeSection:
+		r.blank()
+		r.lines = append(r.lines, headingStyle.Render(node.Text))
+		for i, child := range node.Children {
+			if i > 0 {
+				r.blank()
+			}
+			r.block(child, indent+terminalIndent)
+		}
+	case types.NodeSubSection:
+		r.lines = append(r.lines, strings.Repeat(" ", max(indent-terminalIndent/2, 0))+subHeadingStyle.Render(node.Text))
+	case types.NodeParagraph:
+		r.wrap(node.Spans, indent, indent)
+	case types.NodeTagged:
+		r.wrap(node.Tag, indent, indent)
+		r.wrap(node.Spans, indent+terminalTagIndent, indent+terminalTagIndent)
+	case types.NodeListItem:
+		bullet := node.Text
+		if bullet == "" {
+			bullet = "•"
+		}
+		hang := indent + lipgloss.Width(bullet) + 1
+		start := len(r.lines)
+		r.wrap(node.Spans, hang, hang)
+		if start < len(r.lines) {
+			r.lines[start] = pad + bullet + r.lines[start][indent+lipgloss.Width(bullet):]
+		} else {
+			r.lines = append(r.lines, pad+bullet)
+		}
+	case types.NodeIndent:
+		for i, child := range node.Children {
+			if i > 0 {
+				r.blank()
+			}
+			r.block(child, indent+terminalIndent)
+		}
+	case types.NodeExample:
+		for _, line := range node.Lines {
+			r.lines = append(r.lines, pad+exampleStyle.Render(line))
+		}
+	case types.NodeSynopsis:
+		spans := []types.Span{{Text: node.Text, Bold: true}}
+		for _, arg := range node.Lines {
+			spans = append(spans, types.Span{Text: " " + arg})
+		}
+		r.wrap(spans, indent, indent+lipgloss.Width(node.Text)+1)
+	case types.NodeLink:
+		spans := node.Spans
+		if len(spans) > 0 {
+			spans = append(spans, types.Span{Text: " <"})
+		}
+		start := len(r.lines)
+		r.wrap(append(spans, types.Span{Text: node.Text, Italic: true}), indent, indent)
+		if len(node.Spans) > 0 {
+			// close the angle bracket without breaking the line
+			r.lines[len(r.lines)-1] += ">"
+		}
+		for i := start; i < len(r.lines); i++ {
+			r.lines[i] = strings.Replace(r.lines[i], italicStyle.Render(node.Text), linkStyle.Render(node.Text), 1)
+		}
+	case types.NodeTable:
+		r.table(node, indent)
+	}
+}
+
+// word is a run of text without spaces, possibly made of several fonts.
+type word []types.Span
+
+func (w word) width() int {
+	return lipgloss.Width(types.PlainText(w))
+}
+
+func (w word) render() string {
+	var b strings.Builder
+	for _, span := range w {
+		b.WriteString(renderSpan(span))
+	}
+	return b.String()
+}
+
+// renderSpan applies the font of a span.
+func renderSpan(span types.Span) string {
+	switch {
+	case span.Bold:
+		return boldStyle.Render(span.Text)
+	case span.Italic:
+		return italicStyle.Render(span.Text)
+	}
+	return span.Text
+}
+
+// splitWords splits spans into words, keeping the fonts.
+func splitWords(spans []types.Span) []word {
+	var words []word
+	var current word
+	for _, span := range spans {
+		for i, part := range strings.Split(span.Text, " ") {
+			if i > 0 && len(current) > 0 {
+				words = append(words, current)
+				current = nil
+			}
+			if part != "" {
+				current = append(current, types.Span{Text: part, Bold: span.Bold, Italic: span.Italic})
+			}
+		}
+	}
+	if len(current) > 0 {
+		words = append(words, current)
+	}
+	return words
+}
+
+// wrap fills the spans into lines no wider than the renderer width, the
+// first line indented by first columns and the others by rest.
+func (r *terminalRenderer) wrap(spans []types.Span, first, rest int) {
+	var line strings.Builder
+	indent := first
+	column := 0
+
+	flush := func() {
+		r.lines = append(r.lines, strings.Repeat(" ", indent)+line.String())
+		line.Reset()
+		indent = rest
+		column = 0
+	}
+
+	for _, w := range splitWords(spans) {
+		size := w.width()
+		if column > 0 && indent+column+1+size > r.width {
+			flush()
+		}
+		if column > 0 {
+			line.WriteString(" ")
+			column++
+		}
+		line.WriteString(w.render())
+		column += size
+	}
+	if column > 0 {
+		flush()
+	}
+}
+
+// table renders a table aligning the columns, the last one is wrapped to
+// fit the width.
+func (r *terminalRenderer) table(node *types.Node, indent int) {
+	var widths []int
+	for _, row := range node.Rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+	if len(widths) == 0 {
+		return
+	}
+
+	const gap = 3
+	for i, row := range node.Rows {
+		if len(row) == 0 {
+			r.lines = append(r.lines, "")
+			continue
+		}
+
+		var b strings.Builder
+		b.WriteString(strings.Repeat(" ", indent))
+		for j, cell := range row[:len(row)-1] {
+			text := cell
+			if i == 0 && node.Header {
+				text = boldStyle.Render(cell)
+			}
+			b.WriteString(text + strings.Repeat(" ", widths[j]-lipgloss.Width(cell)+gap))
+		}
+
+		last := lipgloss.Width(b.String())
+		cell := types.Span{Text: row[len(row)-1], Bold: i == 0 && node.Header}
+		start := len(r.lines)
+		r.wrap([]types.Span{cell}, last, last)
+		if start == len(r.lines) {
+			r.lines = append(r.lines, strings.TrimRight(b.String(), " "))
+			continue
+		}
+		r.lines[start] = b.String() + r.lines[start][last:]
+	}
+}
@@ -0,0 +1,170 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vanilla-os/sdk/pkg/v1/roff"
+	"github.com/vanilla-os/sdk/pkg/v1/roff/types"
+)
+
+func newTestDocument() *roff.Document {
+	d := roff.NewDocument()
+	d.Heading(1, "batctl", "Batmobile control", time.Unix(0, 0).UTC())
+	d.Name("batctl", "control the batmobile v2.0")
+
+	d.Section(types.SectionSynopsis)
+	d.Synopsis("batctl use", "[flags]", "<gadget>")
+	d.EndSection()
+
+	d.Section(types.SectionDescription)
+	d.Text("Drive the batmobile from the shell.")
+	d.Paragraph()
+	d.TextBold("Never")
+	d.Text(" leave the .batcave unlocked.")
+	d.EndSection()
+
+	d.Section(types.SectionOptions)
+	d.TaggedParagraph(-1)
+	d.TextBold("-t, --target <value>")
+	d.EndSection()
+	d.Text("Who to use the gadget on")
+	d.EndSection()
+
+	d.Section(types.SectionExamples)
+	d.Example("batctl use batarang --target Joker", `.hidden C:\batcave`)
+	d.EndSection()
+
+	d.Section(types.SectionExitStatus)
+	d.Table([]string{"Code", "Meaning"}, [][]string{
+		{"0", "Success"},
+		{"1", "The batmobile ran out of fuel somewhere in Gotham"},
+	})
+	d.EndSection()
+
+	d.Section(types.SectionSeeAlso)
+	d.References(types.Reference{Name: "apx", Section: "1"})
+	d.Paragraph()
+	d.Link("https://vanillaos.org", "Vanilla OS")
+	d.EndSection()
+	return d
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	doc, err := roff.Parse(newTestDocument().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(doc.Args, []string{"BATCTL", "1", "1970-01-01", "batctl", "Batmobile control"}) {
+		t.Errorf("unexpected heading %q", doc.Args)
+	}
+
+	var headings []string
+	for _, section := range doc.Children {
+		headings = append(headings, section.Text)
+	}
+	expected := []string{"NAME", "SYNOPSIS", "DESCRIPTION", "OPTIONS", "EXAMPLES", "EXIT STATUS", "SEE ALSO"}
+	if !slices.Equal(headings, expected) {
+		t.Fatalf("unexpected sections %q", headings)
+	}
+
+	name := doc.Children[0].Children[0]
+	if got := types.PlainText(name.Spans); got != "batctl - control the batmobile v2.0" {
+		t.Errorf("unexpected name %q", got)
+	}
+
+	synopsis := doc.Children[1].Children[0]
+	if synopsis.Kind != types.NodeSynopsis || synopsis.Text != "batctl use" || !slices.Equal(synopsis.Lines, []string{"[flags]", "<gadget>"}) {
+		t.Errorf("unexpected synopsis %+v", synopsis)
+	}
+
+	description := doc.Children[2].Children
+	if len(description) != 2 {
+		t.Fatalf("expected 2 paragraphs, got %d", len(description))
+	}
+	if spans := description[1].Spans; len(spans) != 2 || !spans[0].Bold || spans[0].Text != "Never" || spans[1].Text != " leave the .batcave unlocked." {
+		t.Errorf("unexpected spans %+v", spans)
+	}
+
+	option := doc.Children[3].Children[0]
+	if option.Kind != types.NodeTagged || types.PlainText(option.Tag) != "-t, --target <value>" || !option.Tag[0].Bold {
+		t.Errorf("unexpected tag %+v", option.Tag)
+	}
+	if types.PlainText(option.Spans) != "Who to use the gadget on" {
+		t.Errorf("unexpected body %+v", option.Spans)
+	}
+
+	example := doc.Children[4].Children[0]
+	if !slices.Equal(example.Lines, []string{"batctl use batarang --target Joker", `.hidden C:\batcave`}) {
+		t.Errorf("unexpected example %q", example.Lines)
+	}
+
+	table := doc.Children[5].Children[0]
+	if !table.Header || len(table.Rows) != 3 || table.Rows[2][1] != "The batmobile ran out of fuel somewhere in Gotham" {
+		t.Errorf("unexpected table %+v", table)
+	}
+
+	seeAlso := doc.Children[6].Children
+	if types.PlainText(seeAlso[0].Spans) != "apx(1)" {
+		t.Errorf("unexpected reference %+v", seeAlso[0].Spans)
+	}
+	if seeAlso[1].Kind != types.NodeLink || seeAlso[1].Text != "https://vanillaos.org" || types.PlainText(seeAlso[1].Spans) != "Vanilla OS" {
+		t.Errorf("unexpected link %+v", seeAlso[1])
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		".SH NAME\n.XX unknown\n",
+		".EX\nnever closed\n",
+	} {
+		if _, err := roff.Parse(src); err == nil {
+			t.Errorf("expected an error parsing %q", src)
+		}
+	}
+
+	_, err := roff.Parse(".SH NAME\n.XX unknown\n")
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("expected the error to report the line, got %v", err)
+	}
+}
+
+func TestRenderTerminal(t *testing.T) {
+	doc, err := roff.Parse(newTestDocument().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := roff.RenderTerminal(doc, 40)
+	for _, line := range strings.Split(out, "\n") {
+		if len([]rune(line)) > 40 {
+			t.Errorf("line wider than 40 columns: %q", line)
+		}
+	}
+
+	for _, expected := range []string{
+		"BATCTL(1)",
+		"\nNAME\n    batctl - control the batmobile v2.0\n",
+		"\nSYNOPSIS\n    batctl use [flags] <gadget>\n",
+		"\n    -t, --target <value>\n        Who to use the gadget on\n",
+		"\n    batctl use batarang --target Joker\n",
+		"\n    Code   Meaning\n",
+		"\n    1      The batmobile ran out of fuel\n           somewhere in Gotham\n",
+		"\n    Vanilla OS <https://vanillaos.org>\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package tests
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Vanilla OS SDK component.
+*/
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/vanilla-os/sdk/pkg/v1/roff"
+	"github.com/vanilla-os/sdk/pkg/v1/roff/types"
+)
+
+func TestDocumentMacros(t *testing.T) {
+	tests := []struct {
+		name     string
+		write    func(d *roff.Document)
+		expected string
+	}{
+		{"name", func(d *roff.Document) {
+			d.Name("batctl", "control the batmobile v2.0")
+		}, ".SH NAME\nbatctl \\- control the batmobile v2\\&.0\n"},
+		{"synopsis", func(d *roff.Document) {
+			d.Synopsis("batctl use", "[flags]", "<gadget>")
+		}, ".SY \"batctl use\"\n[flags]\n<gadget>\n.YS\n"},
+		{"example", func(d *roff.Document) {
+			d.Example(`$ batctl use batarang`, `.hidden C:\batcave`)
+		}, ".EX\n$ batctl use batarang\n\\&.hidden C:\\ebatcave\n.EE\n"},
+		{"link", func(d *roff.Document) {
+			d.Link("https://vanillaos.org", "Vanilla OS")
+		}, ".UR https://vanillaos.org\nVanilla OS\n.UE\n"},
+		{"table", func(d *roff.Document) {
+			d.Table([]string{"Code", "Meaning"}, [][]string{
+				{"0", "Success"},
+				{"1", "The batmobile ran out of fuel somewhere in Gotham"},
+			})
+		}, ".TS\ntab(\t);\nlb lb\nl lx.\nCode\tMeaning\n0\tSuccess\n1\tT{\nThe batmobile ran out of fuel somewhere in Gotham\nT}\n.TE\n"},
+		{"references", func(d *roff.Document) {
+			d.References(
+				types.Reference{Name: "apx", Section: "1"},
+				types.Reference{Name: "abroot", Section: "8"},
+			)
+		}, ".BR apx (1),\n.BR abroot (8)\n"},
+		{"table rules", func(d *roff.Document) {
+			d.Table([]string{"Flag", "Default"}, [][]string{
+				{"--separator", "_"},
+				{"--assign", "="},
+				{"--above", "^"},
+			})
+		}, ".TS\ntab(\t);\nlb lb\nl lx.\nFlag\tDefault\n--separator\t\\&_\n--assign\t\\&=\n--above\t\\&^\n.TE\n"},
+		{"subsection", func(d *roff.Document) {
+			d.SubSection("batctl use")
+		}, ".SS BATCTL USE\n"},
+		{"subsection title", func(d *roff.Document) {
+			d.SubSectionTitle("batctl use")
+		}, ".SS batctl use\n"},
+		{"percent", func(d *roff.Document) {
+			d.Text("100% batman")
+		}, "100% batman"},
+	}
+
+	for _, test := range tests {
+		d := roff.NewDocument()
+		test.write(d)
+		if got := d.String(); got != test.expected {
+			t.Errorf("%s: got %q, expected %q", test.name, got, test.expected)
+		}
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	ref, ok := roff.ParseReference("apx(1)")
+	if !ok || ref.Name != "apx" || ref.Section != "1" {
+		t.Errorf("unexpected reference %+v", ref)
+	}
+
+	for _, invalid := range []string{"apx", "https://vanillaos.org", "(1)", "apx()"} {
+		if _, ok := roff.ParseReference(invalid); ok {
+			t.Errorf("expected %q not to be a reference", invalid)
+		}
+	}
+}
+
+func TestDocumentMandocLint(t *testing.T) {
+	mandoc, err := exec.LookPath("mandoc")
+	if err != nil {
+		t.Skip("mandoc is not installed")
+	}
+
+	d := newTestDocument()
+	d.Section("Commands")
+	d.SubSectionTitle("batctl use")
+	d.Text("Use a gadget.")
+	d.EndSubSection()
+	d.EndSection()
+
+	path := filepath.Join(t.TempDir(), "batctl.1")
+	if err := os.WriteFile(path, []byte(d.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := exec.Command(mandoc, "-Tlint", "-Wwarning", path).CombinedOutput()
+	if err != nil {
+		t.Errorf("mandoc -Tlint failed: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,82 @@
+package types
+
+/*	License: GPLv3
+	Authors:
+		Mirko Brombin <brombin94@gmail.com>
+		Vanilla OS Contributors <https://github.com/vanilla-os/>
+	Copyright: 2026
+	Description: Abstract syntax tree of parsed roff documents.
+*/
+
+// NodeKind is the kind of a node of a parsed roff document.
+type NodeKind int
+
+const (
+	// NodeDocument is the root node, Args holds the .TH arguments: title,
+	// section, date, source and manual.
+	NodeDocument NodeKind = iota
+
+	// NodeSection is a .SH section, Text is the heading and Children the
+	// content.
+	NodeSection
+
+	// NodeSubSection is a .SS heading, the content follows as siblings.
+	NodeSubSection
+
+	// NodeParagraph is a block of filled text made of Spans.
+	NodeParagraph
+
+	// NodeTagged is a .TP paragraph, Tag is the term and Spans the body.
+	NodeTagged
+
+	// NodeListItem is a .IP paragraph, Text is the bullet and Spans the
+	// body.
+	NodeListItem
+
+	// NodeIndent is a .RS/.RE block, its content is in Children.
+	NodeIndent
+
+	// NodeExample is a .EX/.EE block, its verbatim content is in Lines.
+	NodeExample
+
+	// NodeSynopsis is a .SY/.YS block, Text is the command and Lines the
+	// arguments.
+	NodeSynopsis
+
+	// NodeLink is a .UR/.UE hyperlink, Text is the URL and Spans the link
+	// text.
+	NodeLink
+
+	// NodeTable is a .TS/.TE table, Rows holds the cells and Header
+	// reports whether the first row is a header.
+	NodeTable
+)
+
+// Span is a run of text sharing the same font.
+type Span struct {
+	Text   string
+	Bold   bool
+	Italic bool
+}
+
+// Node is a node of a parsed roff document.
+type Node struct {
+	Kind     NodeKind
+	Text     string
+	Args     []string
+	Spans    []Span
+	Tag      []Span
+	Lines    []string
+	Rows     [][]string
+	Header   bool
+	Children []*Node
+}
+
+// PlainText returns the text of the spans without fonts.
+func PlainText(spans []Span) string {
+	var s string
+	for _, span := range spans {
+		s += span.Text
+	}
+	return s
+}
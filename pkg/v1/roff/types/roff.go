@@ -33,6 +33,27 @@ const (
 	// TaggedParagraph macro.
 	TaggedParagraph = "\n.TP"
 
+	// ExampleStart begins an example block, printed verbatim in a
+	// monospaced font.
+	ExampleStart = "\n.EX"
+	// ExampleEnd ends an example block.
+	ExampleEnd = "\n.EE"
+	// SynopsisStart begins the synopsis of a command.
+	SynopsisStart = "\n.SY"
+	// SynopsisEnd ends the synopsis of a command.
+	SynopsisEnd = "\n.YS"
+	// URLStart begins a hyperlink, the link text follows.
+	URLStart = "\n.UR"
+	// URLEnd ends a hyperlink.
+	URLEnd = "\n.UE"
+	// TableStart begins a tbl table.
+	TableStart = "\n.TS"
+	// TableEnd ends a tbl table.
+	TableEnd = "\n.TE"
+	// BoldRoman alternates bold and roman arguments, used for references
+	// to other man pages.
+	BoldRoman = "\n.BR"
+
 	// Bold escape sequence.
 	Bold = `\fB`
 	// Italic escape sequence.
@@ -40,3 +61,23 @@ const (
 	// PreviousFont resets the font.
 	PreviousFont = `\fP`
 )
+
+// Standard man page section names, in their conventional order.
+const (
+	SectionName        = "NAME"
+	SectionSynopsis    = "SYNOPSIS"
+	SectionDescription = "DESCRIPTION"
+	SectionOptions     = "OPTIONS"
+	SectionCommands    = "COMMANDS"
+	SectionExamples    = "EXAMPLES"
+	SectionExitStatus  = "EXIT STATUS"
+	SectionEnvironment = "ENVIRONMENT"
+	SectionFiles       = "FILES"
+	SectionSeeAlso     = "SEE ALSO"
+)
+
+// Reference is a reference to another man page, e.g. apx(1).
+type Reference struct {
+	Name    string
+	Section string
+}
@@ -61,3 +61,14 @@ func TestGetUsers(t *testing.T) {
 	}
 
 }
+
+func TestGetGroups(t *testing.T) {
+	groups := system.GetGroups([]string{"root"}, []string{"0", "999999"}, true)
+
+	if len(groups) != 1 {
+		t.Fatalf("expected only the root group, got %v", groups)
+	}
+	if groups["0"].Name != "root" {
+		t.Errorf("expected root, got %q", groups["0"].Name)
+	}
+}
@@ -219,3 +219,53 @@ func GetAllGroups() ([]types.GroupInfo, error) {
 
 	return groups, nil
 }
+
+// GetGroups retrieves groups with the given names and GIDs. If useGID is
+// true, the returned map will use the GID as the key, otherwise it will use
+// the name as the key.
+//
+// Example:
+//
+//	groups := system.GetGroups([]string{"wheel"}, []string{"1000"}, true)
+//	for gid, group := range groups {
+//		fmt.Printf("GID: %s\n", gid)
+//		fmt.Printf("Name: %s\n", group.Name)
+//	}
+//
+// Notes:
+//
+// groups which do not exist are not available in the returned map.
+func GetGroups(names []string, gids []string, useGID bool) map[string]types.GroupInfo {
+	groupsMap := make(map[string]types.GroupInfo)
+
+	add := func(g *user.Group) {
+		key := g.Name
+		if useGID {
+			key = g.Gid
+		}
+		groupsMap[key] = types.GroupInfo{
+			GID:  g.Gid,
+			Name: g.Name,
+		}
+	}
+
+	for _, name := range names {
+		g, err := user.LookupGroup(name)
+		if err != nil {
+			// If the group doesn't exist, continue
+			continue
+		}
+		add(g)
+	}
+
+	for _, gid := range gids {
+		g, err := user.LookupGroupId(gid)
+		if err != nil {
+			// If the group doesn't exist, continue
+			continue
+		}
+		add(g)
+	}
+
+	return groupsMap
+}